@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"fmt"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// Generators holds the independent, nothing-up-my-sleeve points a range
+// proof of bit length N needs: N-length vectors G, H for the bit-vector
+// commitments, plus the two base points (curve.G, curve.U) used to commit to
+// the value and the inner product argument's blinding generator.
+type Generators struct {
+	N int
+	G []*curve.Point
+	H []*curve.Point
+}
+
+// NewGenerators derives N pairs of vector generators deterministically from
+// curve.MapToGroup, so a verifier can recompute the exact same generators
+// from n alone rather than trusting a prover-supplied setup.
+func NewGenerators(n int) (*Generators, error) {
+	g := make([]*curve.Point, n)
+	h := make([]*curve.Point, n)
+	for i := 0; i < n; i++ {
+		gi, err := curve.MapToGroup(fmt.Sprintf("ZkBNBBulletproofsG%d", i))
+		if err != nil {
+			return nil, err
+		}
+		hi, err := curve.MapToGroup(fmt.Sprintf("ZkBNBBulletproofsH%d", i))
+		if err != nil {
+			return nil, err
+		}
+		g[i] = gi
+		h[i] = hi
+	}
+	return &Generators{N: n, G: g, H: h}, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// innerProductProof is Bulletproofs' logarithmic-size argument that a and b,
+// the vectors folded into commitment P = <g,a> + <h,b> + u*<a,b>, are known
+// to the prover: L and R record each round's cross-term commitments, and
+// A, B are the single scalars left after folding a, b down to length 1.
+type innerProductProof struct {
+	L, R []*curve.Point
+	A, B *big.Int
+}
+
+// proveInnerProduct runs the recursive folding argument over g, h, a, b
+// (all the same power-of-two length), recording each round's L, R in tr so
+// the verifier can recompute the same challenges.
+func proveInnerProduct(tr *transcript, g, h []*curve.Point, u *curve.Point, a, b []*big.Int) *innerProductProof {
+	proof := &innerProductProof{}
+	for len(a) > 1 {
+		n := len(a) / 2
+		gL, gR := g[:n], g[n:]
+		hL, hR := h[:n], h[n:]
+		aL, aR := a[:n], a[n:]
+		bL, bR := b[:n], b[n:]
+
+		cL := innerProductMod(aL, bR)
+		cR := innerProductMod(aR, bL)
+		L := curve.Add(curve.Add(multiScalarMul(gR, aL), multiScalarMul(hL, bR)), curve.ScalarMul(u, cL))
+		R := curve.Add(curve.Add(multiScalarMul(gL, aR), multiScalarMul(hR, bL)), curve.ScalarMul(u, cR))
+		tr.appendPoint(L)
+		tr.appendPoint(R)
+		proof.L = append(proof.L, L)
+		proof.R = append(proof.R, R)
+
+		x := tr.challengeScalar()
+		xInv := ffmath.ModInverse(x, curve.Order)
+
+		g, h = foldGenerators(gL, gR, xInv, x), foldGenerators(hL, hR, x, xInv)
+		a = vecAddMod(vecScalarMulMod(aL, x), vecScalarMulMod(aR, xInv))
+		b = vecAddMod(vecScalarMulMod(bL, xInv), vecScalarMulMod(bR, x))
+	}
+	proof.A, proof.B = a[0], b[0]
+	return proof
+}
+
+// verifyInnerProduct recomputes the same fold the prover performed (driven
+// by the same Fiat-Shamir transcript) and checks that the claimed P
+// (adjusted round by round by each L, R) equals what A, B actually commit
+// to against the fully-folded generators.
+func verifyInnerProduct(tr *transcript, g, h []*curve.Point, u *curve.Point, p *curve.Point, proof *innerProductProof) error {
+	if len(proof.L) != len(proof.R) {
+		return errors.New("inner product proof: mismatched L/R round count")
+	}
+	for i := range proof.L {
+		n := len(g) / 2
+		gL, gR := g[:n], g[n:]
+		hL, hR := h[:n], h[n:]
+
+		tr.appendPoint(proof.L[i])
+		tr.appendPoint(proof.R[i])
+		x := tr.challengeScalar()
+		xInv := ffmath.ModInverse(x, curve.Order)
+
+		g, h = foldGenerators(gL, gR, xInv, x), foldGenerators(hL, hR, x, xInv)
+		xSq := ffmath.MultiplyMod(x, x, curve.Order)
+		xInvSq := ffmath.MultiplyMod(xInv, xInv, curve.Order)
+		p = curve.Add(curve.Add(p, curve.ScalarMul(proof.L[i], xSq)), curve.ScalarMul(proof.R[i], xInvSq))
+	}
+	if len(g) != 1 {
+		return errors.New("inner product proof: generators did not fold down to length 1")
+	}
+	want := curve.Add(curve.Add(curve.ScalarMul(g[0], proof.A), curve.ScalarMul(h[0], proof.B)),
+		curve.ScalarMul(u, ffmath.MultiplyMod(proof.A, proof.B, curve.Order)))
+	if !want.X.Equal(&p.X) || !want.Y.Equal(&p.Y) {
+		return errors.New("inner product proof: final check failed")
+	}
+	return nil
+}
+
+// foldGenerators halves a generator vector: out_i = left_i*lCoeff + right_i*rCoeff.
+func foldGenerators(left, right []*curve.Point, lCoeff, rCoeff *big.Int) []*curve.Point {
+	out := make([]*curve.Point, len(left))
+	for i := range left {
+		out[i] = curve.Add(curve.ScalarMul(left[i], lCoeff), curve.ScalarMul(right[i], rCoeff))
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// RangeProof proves, for a Pedersen commitment V = value*curve.G +
+// gamma*curve.H, that value lies in [0, 2^gens.N). It reveals TauX, Mu, and
+// THat in the clear (standard for Bulletproofs: they're additively blinded
+// by gamma/alpha/rho and leak nothing about value on their own) and defers
+// proving THat is the actual inner product of the proof's hidden l, r
+// vectors to the inner product argument.
+type RangeProof struct {
+	A, S   *curve.Point
+	T1, T2 *curve.Point
+	TauX   *big.Int
+	Mu     *big.Int
+	THat   *big.Int
+	ipa    *innerProductProof
+}
+
+// Prove builds a commitment to value and a RangeProof that it lies in
+// [0, 2^gens.N). gamma is the commitment's own blinding factor; the caller
+// generates it (e.g. via curve.RandomValue()) and must keep it to later
+// open V, the same way curve.ElGamalEncrypt callers keep their randomizer.
+func Prove(gens *Generators, value uint64, gamma *big.Int) (*curve.Point, *RangeProof, error) {
+	n := gens.N
+	if n < 64 && value >= uint64(1)<<uint(n) {
+		return nil, nil, errors.New("bulletproofs: value does not fit in the configured bit length")
+	}
+
+	v := new(big.Int).SetUint64(value)
+	V := curve.Add(curve.ScalarBaseMul(v), curve.ScalarMul(curve.H, gamma))
+
+	aL := make([]*big.Int, n)
+	aR := make([]*big.Int, n)
+	one := big.NewInt(1)
+	for i := 0; i < n; i++ {
+		if value&(uint64(1)<<uint(i)) != 0 {
+			aL[i] = big.NewInt(1)
+			aR[i] = big.NewInt(0)
+		} else {
+			aL[i] = big.NewInt(0)
+			aR[i] = ffmath.SubMod(big.NewInt(0), one, curve.Order)
+		}
+	}
+
+	alpha := curve.RandomValue()
+	A := curve.Add(curve.Add(curve.ScalarMul(curve.H, alpha), multiScalarMul(gens.G, aL)), multiScalarMul(gens.H, aR))
+
+	sL := randomVector(n)
+	sR := randomVector(n)
+	rho := curve.RandomValue()
+	S := curve.Add(curve.Add(curve.ScalarMul(curve.H, rho), multiScalarMul(gens.G, sL)), multiScalarMul(gens.H, sR))
+
+	tr := newTranscript("ZkBNBBulletproofsRangeProof")
+	tr.appendPoint(V)
+	tr.appendPoint(A)
+	tr.appendPoint(S)
+	y := tr.challengeScalar()
+	z := tr.challengeScalar()
+
+	yPow := powersOf(y, n)
+	twoPow := powersOf(big.NewInt(2), n)
+	zSq := ffmath.MultiplyMod(z, z, curve.Order)
+
+	zVec := make([]*big.Int, n)
+	for i := range zVec {
+		zVec[i] = z
+	}
+	l0 := vecSubMod(aL, zVec)
+	l1 := sL
+	r0 := vecAddMod(vecHadamardMod(yPow, vecAddMod(aR, zVec)), vecScalarMulMod(twoPow, zSq))
+	r1 := vecHadamardMod(yPow, sR)
+
+	t1 := ffmath.AddMod(innerProductMod(l0, r1), innerProductMod(l1, r0), curve.Order)
+	t2 := innerProductMod(l1, r1)
+
+	tau1 := curve.RandomValue()
+	tau2 := curve.RandomValue()
+	T1 := curve.Add(curve.ScalarBaseMul(t1), curve.ScalarMul(curve.H, tau1))
+	T2 := curve.Add(curve.ScalarBaseMul(t2), curve.ScalarMul(curve.H, tau2))
+
+	tr.appendPoint(T1)
+	tr.appendPoint(T2)
+	x := tr.challengeScalar()
+
+	l := vecAddMod(l0, vecScalarMulMod(l1, x))
+	r := vecAddMod(r0, vecScalarMulMod(r1, x))
+	that := innerProductMod(l, r)
+
+	xSq := ffmath.MultiplyMod(x, x, curve.Order)
+	tauX := ffmath.AddMod(ffmath.AddMod(ffmath.MultiplyMod(tau2, xSq, curve.Order), ffmath.MultiplyMod(tau1, x, curve.Order), curve.Order),
+		ffmath.MultiplyMod(zSq, gamma, curve.Order), curve.Order)
+	mu := ffmath.AddMod(alpha, ffmath.MultiplyMod(rho, x, curve.Order), curve.Order)
+
+	hPrime := scalePoints(gens.H, powersOf(ffmath.ModInverse(y, curve.Order), n))
+
+	tr.appendScalar(tauX)
+	tr.appendScalar(mu)
+	tr.appendScalar(that)
+	ipaProof := proveInnerProduct(tr, gens.G, hPrime, curve.U, l, r)
+
+	return V, &RangeProof{A: A, S: S, T1: T1, T2: T2, TauX: tauX, Mu: mu, THat: that, ipa: ipaProof}, nil
+}
+
+// Verify checks that proof attests V commits to a value in [0, 2^gens.N).
+func Verify(gens *Generators, v *curve.Point, proof *RangeProof) error {
+	n := gens.N
+	tr := newTranscript("ZkBNBBulletproofsRangeProof")
+	tr.appendPoint(v)
+	tr.appendPoint(proof.A)
+	tr.appendPoint(proof.S)
+	y := tr.challengeScalar()
+	z := tr.challengeScalar()
+
+	yPow := powersOf(y, n)
+	twoPow := powersOf(big.NewInt(2), n)
+	zSq := ffmath.MultiplyMod(z, z, curve.Order)
+
+	tr.appendPoint(proof.T1)
+	tr.appendPoint(proof.T2)
+	x := tr.challengeScalar()
+	xSq := ffmath.MultiplyMod(x, x, curve.Order)
+
+	sumY := big.NewInt(0)
+	for _, p := range yPow {
+		sumY = ffmath.AddMod(sumY, p, curve.Order)
+	}
+	sumTwo := big.NewInt(0)
+	for _, p := range twoPow {
+		sumTwo = ffmath.AddMod(sumTwo, p, curve.Order)
+	}
+	zCube := ffmath.MultiplyMod(zSq, z, curve.Order)
+	delta := ffmath.SubMod(ffmath.MultiplyMod(ffmath.SubMod(z, zSq, curve.Order), sumY, curve.Order),
+		ffmath.MultiplyMod(zCube, sumTwo, curve.Order), curve.Order)
+
+	lhs := curve.Add(curve.ScalarBaseMul(proof.THat), curve.ScalarMul(curve.H, proof.TauX))
+	rhs := curve.Add(curve.Add(curve.ScalarMul(v, zSq), curve.ScalarBaseMul(delta)),
+		curve.Add(curve.ScalarMul(proof.T1, x), curve.ScalarMul(proof.T2, xSq)))
+	if !lhs.X.Equal(&rhs.X) || !lhs.Y.Equal(&rhs.Y) {
+		return errors.New("bulletproofs: t_hat/tau_x check failed")
+	}
+
+	hPrime := scalePoints(gens.H, powersOf(ffmath.ModInverse(y, curve.Order), n))
+	pIPA := ipaCommitment(gens.G, gens.H, hPrime, proof.A, proof.S, x, z, twoPow, proof.THat, proof.Mu)
+
+	tr.appendScalar(proof.TauX)
+	tr.appendScalar(proof.Mu)
+	tr.appendScalar(proof.THat)
+	return verifyInnerProduct(tr, gens.G, hPrime, curve.U, pIPA, proof.ipa)
+}
+
+// ipaCommitment computes P = A + x*S - z*sum(g) + z*sum(h) + z^2*<h',2^n>
+// - mu*H + that*U, the single multi-exponentiation both Prove and Verify
+// feed into the inner product argument: subtracting mu*H cancels A and S's
+// own blinding (h^mu in the paper's notation), leaving <g,l> + <h',r>, and
+// adding that*U binds the claimed inner product THat into the argument
+// itself rather than leaving it as a separate unchecked claim.
+func ipaCommitment(g, h, hPrime []*curve.Point, A, S *curve.Point, x, z *big.Int, twoPow []*big.Int, that, mu *big.Int) *curve.Point {
+	n := len(g)
+	zVec := make([]*big.Int, n)
+	for i := range zVec {
+		zVec[i] = z
+	}
+	zSq := ffmath.MultiplyMod(z, z, curve.Order)
+
+	p := curve.Add(A, curve.ScalarMul(S, x))
+	p = curve.Add(p, curve.Neg(multiScalarMul(g, zVec)))
+	p = curve.Add(p, multiScalarMul(h, zVec))
+	p = curve.Add(p, multiScalarMul(hPrime, vecScalarMulMod(twoPow, zSq)))
+	p = curve.Add(p, curve.Neg(curve.ScalarMul(curve.H, mu)))
+	p = curve.Add(p, curve.ScalarMul(curve.U, that))
+	return p
+}
+
+func scalePoints(points []*curve.Point, scalars []*big.Int) []*curve.Point {
+	out := make([]*curve.Point, len(points))
+	for i := range points {
+		out[i] = curve.ScalarMul(points[i], scalars[i])
+	}
+	return out
+}
+
+func randomVector(n int) []*big.Int {
+	out := make([]*big.Int, n)
+	for i := range out {
+		out[i] = curve.RandomValue()
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"math/big"
+	"testing"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func TestProveVerifyAggregatedRoundTrips(t *testing.T) {
+	const n = 32
+	// A transaction's three balance deltas, padded with a fourth zero-value
+	// entry: n*len(values) must be a power of two (see ProveAggregated).
+	values := []uint64{0, 42, 1<<32 - 1, 0}
+	gens, err := NewGenerators(n * len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gammas := make([]*big.Int, len(values))
+	for i := range gammas {
+		gammas[i] = curve.RandomValue()
+	}
+	Vs, proof, err := ProveAggregated(gens, n, values, gammas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAggregated(gens, n, Vs, proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProveAggregatedRejectsValueOutOfRange(t *testing.T) {
+	const n = 8
+	values := []uint64{1, 256, 3, 0}
+	gens, err := NewGenerators(n * len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gammas := []*big.Int{curve.RandomValue(), curve.RandomValue(), curve.RandomValue(), curve.RandomValue()}
+	if _, _, err := ProveAggregated(gens, n, values, gammas); err == nil {
+		t.Fatal("expected an error for a value that doesn't fit in 8 bits")
+	}
+}
+
+func TestProveAggregatedRejectsNonPowerOfTwoLength(t *testing.T) {
+	const n = 8
+	values := []uint64{1, 2, 3}
+	gens, err := NewGenerators(n * len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gammas := []*big.Int{curve.RandomValue(), curve.RandomValue(), curve.RandomValue()}
+	if _, _, err := ProveAggregated(gens, n, values, gammas); err == nil {
+		t.Fatal("expected an error since n*len(values) is not a power of two")
+	}
+}
+
+func TestProveAggregatedRejectsMismatchedGammas(t *testing.T) {
+	const n = 8
+	gens, err := NewGenerators(n * 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ProveAggregated(gens, n, []uint64{1, 2}, []*big.Int{curve.RandomValue()}); err == nil {
+		t.Fatal("expected an error for mismatched values/gammas lengths")
+	}
+}
+
+func TestVerifyAggregatedRejectsTamperedCommitment(t *testing.T) {
+	const n = 16
+	values := []uint64{5, 1000, 7, 0}
+	gens, err := NewGenerators(n * len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gammas := make([]*big.Int, len(values))
+	for i := range gammas {
+		gammas[i] = curve.RandomValue()
+	}
+	Vs, proof, err := ProveAggregated(gens, n, values, gammas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Vs[1] = curve.Add(Vs[1], curve.ScalarBaseMul(curve.RandomValue()))
+	if err := VerifyAggregated(gens, n, Vs, proof); err == nil {
+		t.Fatal("expected verification to fail for a tampered commitment")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"testing"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// BenchmarkProve and BenchmarkVerify measure this package's own cost in
+// isolation. There is no CtRangeProof or comRange type anywhere in this
+// tree to benchmark against (see the package doc comment in
+// bulletproofs.go) — balances here are proved in the clear, not as
+// Pedersen-committed values — so these benchmarks report Bulletproofs'
+// absolute numbers rather than a side-by-side comparison.
+func BenchmarkProve(b *testing.B) {
+	gens, err := NewGenerators(32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	gamma := curve.RandomValue()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Prove(gens, 123456, gamma); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	gens, err := NewGenerators(32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	gamma := curve.RandomValue()
+	V, proof, err := Prove(gens, 123456, gamma)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Verify(gens, V, proof); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkProofSize reports this package's proof size (point and scalar
+// count) instead of a timing, since proof size doesn't vary run to run: a
+// 32-bit range proof holds 4 fixed points (A, S, T1, T2), 3 scalars
+// (TauX, Mu, THat), and an inner product argument with log2(32)=5 rounds of
+// 2 points each plus 2 final scalars — 4 + 2*5 = 14 points and 3 + 2 = 5
+// scalars total, versus the O(n) points a bit-commitment range proof over
+// the same 32-bit range would need.
+func BenchmarkProofSize(b *testing.B) {
+	gens, err := NewGenerators(32)
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, proof, err := Prove(gens, 123456, curve.RandomValue())
+	if err != nil {
+		b.Fatal(err)
+	}
+	points := 4 + 2*len(proof.ipa.L)
+	scalars := 3 + 2
+	b.ReportMetric(float64(points), "points")
+	b.ReportMetric(float64(scalars), "scalars")
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"testing"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func TestProveVerifyRoundTrips(t *testing.T) {
+	gens, err := NewGenerators(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []uint64{0, 1, 42, 1000, 1<<32 - 1} {
+		gamma := curve.RandomValue()
+		V, proof, err := Prove(gens, value, gamma)
+		if err != nil {
+			t.Fatalf("value %d: %v", value, err)
+		}
+		if err := Verify(gens, V, proof); err != nil {
+			t.Fatalf("value %d: %v", value, err)
+		}
+	}
+}
+
+func TestProveRejectsValueOutOfRange(t *testing.T) {
+	gens, err := NewGenerators(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Prove(gens, 256, curve.RandomValue()); err == nil {
+		t.Fatal("expected an error for a value that doesn't fit in 8 bits")
+	}
+}
+
+func TestVerifyRejectsTamperedCommitment(t *testing.T) {
+	gens, err := NewGenerators(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamma := curve.RandomValue()
+	V, proof, err := Prove(gens, 100, gamma)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrongV := curve.Add(V, curve.ScalarBaseMul(curve.RandomValue()))
+	if err := Verify(gens, wrongV, proof); err == nil {
+		t.Fatal("expected verification to fail for a tampered commitment")
+	}
+}
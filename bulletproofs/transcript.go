@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// transcript accumulates the public values a Bulletproofs round exchanges
+// and derives Fiat-Shamir challenges from them, so the prover and verifier
+// agree on the same non-interactive challenges without an actual
+// interactive round trip. Every challenge also gets folded back into the
+// transcript, so later challenges depend on earlier ones.
+type transcript struct {
+	buf []byte
+}
+
+func newTranscript(label string) *transcript {
+	return &transcript{buf: []byte(label)}
+}
+
+func (t *transcript) appendPoint(p *curve.Point) {
+	t.buf = append(t.buf, curve.ToBytes(p)...)
+}
+
+func (t *transcript) appendScalar(s *big.Int) {
+	t.buf = append(t.buf, s.FillBytes(make([]byte, 32))...)
+}
+
+// challengeScalar hashes everything absorbed so far into a scalar mod the
+// curve order, then folds the resulting bytes back into the transcript so
+// the next challenge it produces differs from this one.
+func (t *transcript) challengeScalar() *big.Int {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(t.buf)
+	digest := hFunc.Sum(nil)
+	t.buf = append(t.buf, digest...)
+	return ffmath.Mod(new(big.Int).SetBytes(digest), curve.Order)
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bulletproofs
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func vecAddMod(a, b []*big.Int) []*big.Int {
+	out := make([]*big.Int, len(a))
+	for i := range a {
+		out[i] = ffmath.AddMod(a[i], b[i], curve.Order)
+	}
+	return out
+}
+
+func vecSubMod(a, b []*big.Int) []*big.Int {
+	out := make([]*big.Int, len(a))
+	for i := range a {
+		out[i] = ffmath.SubMod(a[i], b[i], curve.Order)
+	}
+	return out
+}
+
+func vecHadamardMod(a, b []*big.Int) []*big.Int {
+	out := make([]*big.Int, len(a))
+	for i := range a {
+		out[i] = ffmath.MultiplyMod(a[i], b[i], curve.Order)
+	}
+	return out
+}
+
+func vecScalarMulMod(a []*big.Int, s *big.Int) []*big.Int {
+	out := make([]*big.Int, len(a))
+	for i := range a {
+		out[i] = ffmath.MultiplyMod(a[i], s, curve.Order)
+	}
+	return out
+}
+
+func innerProductMod(a, b []*big.Int) *big.Int {
+	sum := big.NewInt(0)
+	for i := range a {
+		sum = ffmath.AddMod(sum, ffmath.MultiplyMod(a[i], b[i], curve.Order), curve.Order)
+	}
+	return sum
+}
+
+// powersOf returns [1, x, x^2, ..., x^{n-1}] mod the curve order.
+func powersOf(x *big.Int, n int) []*big.Int {
+	out := make([]*big.Int, n)
+	out[0] = big.NewInt(1)
+	for i := 1; i < n; i++ {
+		out[i] = ffmath.MultiplyMod(out[i-1], x, curve.Order)
+	}
+	return out
+}
+
+// multiScalarMul returns sum_i points[i]*scalars[i].
+func multiScalarMul(points []*curve.Point, scalars []*big.Int) *curve.Point {
+	acc := curve.ZeroPoint()
+	for i := range points {
+		acc = curve.Add(acc, curve.ScalarMul(points[i], scalars[i]))
+	}
+	return acc
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// AccountNetFlow sums, per asset id, the balance change accountIndex
+// experiences across txs. It covers the tx types that move an asset balance
+// directly between accounts (TransferTxInfo, WithdrawTxInfo, DepositTxInfo,
+// AtomicMatchTxInfo, FullExitTxInfo); other tx types either don't move an
+// asset balance for a non-gas-paying account (RegisterZns, CreateCollection,
+// MintNft, TransferNft, CancelOffer, DepositNft/FullExitNft, which move nft
+// ownership rather than an asset balance) or aren't reachable from a Tx
+// built outside a full block (the gas account's running balance lives in
+// GasConstraints, not on the Tx itself), and are skipped.
+func AccountNetFlow(accountIndex int64, txs []*Tx) (map[int64]*big.Int, error) {
+	flow := make(map[int64]*big.Int)
+	add := func(assetId int64, delta *big.Int) {
+		if delta.Sign() == 0 {
+			return
+		}
+		current, ok := flow[assetId]
+		if !ok {
+			current = big.NewInt(0)
+			flow[assetId] = current
+		}
+		current.Add(current, delta)
+	}
+
+	for i, tx := range txs {
+		if tx == nil {
+			return nil, fmt.Errorf("tx[%d] should not be nil", i)
+		}
+		switch tx.TxType {
+		case types.TxTypeTransfer:
+			info := tx.TransferTxInfo
+			if info == nil {
+				return nil, fmt.Errorf("tx[%d]: TransferTxInfo should not be nil", i)
+			}
+			if accountIndex == info.FromAccountIndex {
+				add(info.AssetId, big.NewInt(-info.AssetAmount))
+				add(info.GasFeeAssetId, big.NewInt(-info.GasFeeAssetAmount))
+			}
+			if accountIndex == info.ToAccountIndex {
+				add(info.AssetId, big.NewInt(info.AssetAmount))
+			}
+			if accountIndex == info.GasAccountIndex {
+				add(info.GasFeeAssetId, big.NewInt(info.GasFeeAssetAmount))
+			}
+		case types.TxTypeWithdraw:
+			info := tx.WithdrawTxInfo
+			if info == nil {
+				return nil, fmt.Errorf("tx[%d]: WithdrawTxInfo should not be nil", i)
+			}
+			if accountIndex == info.FromAccountIndex {
+				add(info.AssetId, new(big.Int).Neg(info.AssetAmount))
+				add(info.GasFeeAssetId, big.NewInt(-info.GasFeeAssetAmount))
+			}
+			if accountIndex == info.GasAccountIndex {
+				add(info.GasFeeAssetId, big.NewInt(info.GasFeeAssetAmount))
+			}
+		case types.TxTypeDeposit:
+			info := tx.DepositTxInfo
+			if info == nil {
+				return nil, fmt.Errorf("tx[%d]: DepositTxInfo should not be nil", i)
+			}
+			if accountIndex == info.AccountIndex {
+				add(info.AssetId, new(big.Int).Set(info.AssetAmount))
+			}
+		case types.TxTypeFullExit:
+			info := tx.FullExitTxInfo
+			if info == nil {
+				return nil, fmt.Errorf("tx[%d]: FullExitTxInfo should not be nil", i)
+			}
+			// FullExitTxInfo.AssetAmount is the account's balance before the
+			// exit (VerifyFullExitTx asserts it equals
+			// accountsBefore[0].AssetsInfo[0].Balance), so the account's
+			// delta is the full balance leaving, not an amount the exit
+			// itself carries as a positive/negative choice.
+			if accountIndex == info.AccountIndex {
+				add(info.AssetId, new(big.Int).Neg(info.AssetAmount))
+			}
+		case types.TxTypeAtomicMatch:
+			info := tx.AtomicMatchTxInfo
+			if info == nil {
+				return nil, fmt.Errorf("tx[%d]: AtomicMatchTxInfo should not be nil", i)
+			}
+			if info.BuyOffer == nil || info.SellOffer == nil {
+				return nil, fmt.Errorf("tx[%d]: AtomicMatchTxInfo offers should not be nil", i)
+			}
+			assetId := info.BuyOffer.AssetId
+			price := info.BuyOffer.AssetAmount
+			sellerAmount := price - info.CreatorAmount - info.TreasuryAmount
+			if accountIndex == info.BuyOffer.AccountIndex {
+				add(assetId, big.NewInt(-price))
+			}
+			if accountIndex == info.SellOffer.AccountIndex {
+				add(assetId, big.NewInt(sellerAmount))
+			}
+			if tx.NftBefore != nil && accountIndex == tx.NftBefore.CreatorAccountIndex {
+				add(assetId, big.NewInt(info.CreatorAmount))
+			}
+			if accountIndex == info.AccountIndex {
+				add(info.GasFeeAssetId, big.NewInt(-info.GasFeeAssetAmount))
+			}
+			if accountIndex == info.GasAccountIndex {
+				add(assetId, big.NewInt(info.TreasuryAmount))
+				add(info.GasFeeAssetId, big.NewInt(info.GasFeeAssetAmount))
+			}
+		}
+	}
+	return flow, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func TestAccountNetFlow(t *testing.T) {
+	const accountIndex = int64(5)
+
+	transferIn := &Tx{
+		TxType: uint8(types.TxTypeTransfer),
+		TransferTxInfo: &TransferTx{
+			FromAccountIndex:  1,
+			ToAccountIndex:    accountIndex,
+			AssetId:           0,
+			AssetAmount:       100,
+			GasAccountIndex:   9,
+			GasFeeAssetId:     1,
+			GasFeeAssetAmount: 10,
+		},
+	}
+
+	swap := &Tx{
+		TxType: uint8(types.TxTypeAtomicMatch),
+		AtomicMatchTxInfo: &AtomicMatchTx{
+			AccountIndex: 2,
+			BuyOffer: &types.OfferTx{
+				AccountIndex: 2,
+				AssetId:      0,
+				AssetAmount:  500,
+			},
+			SellOffer: &types.OfferTx{
+				AccountIndex: accountIndex,
+				AssetId:      0,
+				AssetAmount:  500,
+			},
+			CreatorAmount:     20,
+			TreasuryAmount:    10,
+			GasAccountIndex:   9,
+			GasFeeAssetId:     1,
+			GasFeeAssetAmount: 5,
+		},
+		NftBefore: &types.Nft{
+			CreatorAccountIndex: 3,
+		},
+	}
+
+	flow, err := AccountNetFlow(accountIndex, []*Tx{transferIn, swap})
+	require.NoError(t, err)
+	require.Equal(t, 0, flow[0].Cmp(big.NewInt(570)))
+	require.NotContains(t, flow, int64(1))
+
+	_, err = AccountNetFlow(accountIndex, []*Tx{nil})
+	require.Error(t, err)
+}
+
+func TestAccountNetFlowFullExit(t *testing.T) {
+	const accountIndex = int64(5)
+
+	fullExit := &Tx{
+		TxType: uint8(types.TxTypeFullExit),
+		FullExitTxInfo: &FullExitTx{
+			AccountIndex: accountIndex,
+			AssetId:      0,
+			AssetAmount:  big.NewInt(250),
+		},
+	}
+
+	flow, err := AccountNetFlow(accountIndex, []*Tx{fullExit})
+	require.NoError(t, err)
+	require.Equal(t, 0, flow[0].Cmp(big.NewInt(-250)))
+
+	// a FullExit for a different account leaves accountIndex's flow empty.
+	otherAccountFullExit := &Tx{
+		TxType: uint8(types.TxTypeFullExit),
+		FullExitTxInfo: &FullExitTx{
+			AccountIndex: accountIndex + 1,
+			AssetId:      0,
+			AssetAmount:  big.NewInt(250),
+		},
+	}
+	flow, err = AccountNetFlow(accountIndex, []*Tx{otherAccountFullExit})
+	require.NoError(t, err)
+	require.NotContains(t, flow, int64(0))
+
+	missingInfo := &Tx{TxType: uint8(types.TxTypeFullExit)}
+	_, err = AccountNetFlow(accountIndex, []*Tx{missingInfo})
+	require.Error(t, err)
+}
@@ -310,11 +310,11 @@ func GetAssetDeltasAndNftDeltaFromAtomicMatch(
 		},
 		EmptyAccountAssetDeltaConstraints(),
 	}
-	// TODO
-	creatorAmountVar := api.Mul(txInfo.BuyOffer.AssetAmount, nftBefore.CreatorTreasuryRate)
-	treasuryAmountVar := api.Mul(txInfo.BuyOffer.AssetAmount, txInfo.BuyOffer.TreasuryRate)
-	creatorAmountVar = api.Div(creatorAmountVar, RateBase)
-	treasuryAmountVar = api.Div(treasuryAmountVar, RateBase)
+	// creator and treasury shares round down (SplitSalePrice's floor-to-creator
+	// rule, applied once per share so each is independently integer-exact);
+	// any fractional remainder from either division stays with the seller.
+	creatorAmountVar, _ := SplitSalePrice(api, txInfo.BuyOffer.AssetAmount, nftBefore.CreatorTreasuryRate)
+	treasuryAmountVar, _ := SplitSalePrice(api, txInfo.BuyOffer.AssetAmount, txInfo.BuyOffer.TreasuryRate)
 	sellerAmount := api.Sub(txInfo.BuyOffer.AssetAmount, api.Add(creatorAmountVar, treasuryAmountVar))
 	buyerDelta := api.Neg(txInfo.BuyOffer.AssetAmount)
 	sellerDelta := sellerAmount
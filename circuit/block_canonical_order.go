@@ -0,0 +1,67 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// accountIndexOf returns the submitting account for oTx, the same account
+// AssertNonceMatchesAccount checks the nonce against.
+func accountIndexOf(oTx *Tx) int64 {
+	if oTx.AccountsInfoBefore[0] == nil {
+		return 0
+	}
+	return oTx.AccountsInfoBefore[0].AccountIndex
+}
+
+// CanonicalizeBlock returns txs reordered into this tree's canonical block
+// order: grouped by submitting account, then by nonce within each account.
+// The sort is stable, so txs tying on both keys (non-layer2 tx types, whose
+// nonce carries no meaning per isLayer2TxType) keep their relative input
+// order. For layer2 tx types it also requires an account's nonces to run
+// consecutively (matching the +1-per-tx nonce the circuit commits to, see
+// AssertNonceMatchesAccount), catching a gap or a duplicate before the tx
+// set is admitted to a block.
+func CanonicalizeBlock(txs []*Tx) ([]*Tx, error) {
+	ordered := make([]*Tx, len(txs))
+	copy(ordered, txs)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ai, aj := accountIndexOf(ordered[i]), accountIndexOf(ordered[j])
+		if ai != aj {
+			return ai < aj
+		}
+		return ordered[i].Nonce < ordered[j].Nonce
+	})
+
+	var lastAccount, lastNonce int64
+	haveLast := false
+	for _, oTx := range ordered {
+		if !isLayer2TxType(oTx.TxType) {
+			continue
+		}
+		account := accountIndexOf(oTx)
+		if haveLast && account == lastAccount && oTx.Nonce != lastNonce+1 {
+			return nil, fmt.Errorf("account %d: nonce %d does not follow %d", account, oTx.Nonce, lastNonce)
+		}
+		lastAccount, lastNonce, haveLast = account, oTx.Nonce, true
+	}
+	return ordered, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func txWithAccountNonce(accountIndex, nonce int64) *Tx {
+	return &Tx{
+		TxType:             types.TxTypeTransfer,
+		Nonce:              nonce,
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{AccountIndex: accountIndex, Nonce: nonce}},
+	}
+}
+
+// TestCanonicalizeBlockIsStableUnderShuffling checks that however the same
+// set of txs is ordered on input, CanonicalizeBlock produces the same
+// account-then-nonce order.
+func TestCanonicalizeBlockIsStableUnderShuffling(t *testing.T) {
+	inOrder := []*Tx{
+		txWithAccountNonce(1, 0),
+		txWithAccountNonce(1, 1),
+		txWithAccountNonce(2, 0),
+		txWithAccountNonce(2, 1),
+		txWithAccountNonce(3, 0),
+	}
+	shuffled := []*Tx{
+		inOrder[4], inOrder[1], inOrder[2], inOrder[0], inOrder[3],
+	}
+
+	got, err := CanonicalizeBlock(shuffled)
+	require.NoError(t, err)
+	require.Equal(t, inOrder, got)
+
+	// canonicalizing an already-canonical block is a no-op.
+	again, err := CanonicalizeBlock(inOrder)
+	require.NoError(t, err)
+	require.Equal(t, inOrder, again)
+}
+
+func TestCanonicalizeBlockRejectsNonceGap(t *testing.T) {
+	txs := []*Tx{
+		txWithAccountNonce(1, 0),
+		txWithAccountNonce(1, 2),
+	}
+	_, err := CanonicalizeBlock(txs)
+	require.Error(t, err)
+}
+
+func TestCanonicalizeBlockRejectsDuplicateNonce(t *testing.T) {
+	txs := []*Tx{
+		txWithAccountNonce(1, 0),
+		txWithAccountNonce(1, 0),
+	}
+	_, err := CanonicalizeBlock(txs)
+	require.Error(t, err)
+}
+
+// TestCanonicalizeBlockSkipsNonceCheckForNonLayer2Tx checks that tx types
+// without a meaningful nonce (e.g. deposits) are sorted but not subject to
+// the strictly-increasing nonce requirement.
+func TestCanonicalizeBlockSkipsNonceCheckForNonLayer2Tx(t *testing.T) {
+	txs := []*Tx{
+		{TxType: types.TxTypeDeposit, AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{AccountIndex: 1}}},
+		{TxType: types.TxTypeDeposit, AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{AccountIndex: 1}}},
+	}
+	_, err := CanonicalizeBlock(txs)
+	require.NoError(t, err)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MergeTxWitnesses folds single-tx witnesses built independently (e.g. by
+// SetTxWitness during prep) into the Txs/TxsCount/OldStateRoot/NewStateRoot
+// fields of a BlockConstraints witness, checking that each tx's
+// StateRootAfter chains into the next tx's StateRootBefore the same way
+// VerifyBlock does in-circuit.
+//
+// It cannot populate BlockNumber, CreatedAt, BlockCommitment or Gas: those
+// depend on data outside the individual tx witnesses (the block header and
+// the gas account) and must still be filled in by the caller, e.g. via
+// SetBlockWitness or by assigning them directly before proving.
+func MergeTxWitnesses(ws []TxConstraints) (BlockConstraints, error) {
+	if len(ws) == 0 {
+		return BlockConstraints{}, fmt.Errorf("no tx witnesses to merge")
+	}
+	for i := 1; i < len(ws); i++ {
+		after, ok := witnessValueToBigInt(reflect.ValueOf(ws[i-1].StateRootAfter))
+		if !ok {
+			return BlockConstraints{}, fmt.Errorf("tx %d: StateRootAfter is not a scalar witness value", i-1)
+		}
+		before, ok := witnessValueToBigInt(reflect.ValueOf(ws[i].StateRootBefore))
+		if !ok {
+			return BlockConstraints{}, fmt.Errorf("tx %d: StateRootBefore is not a scalar witness value", i)
+		}
+		if after.Cmp(before) != 0 {
+			return BlockConstraints{}, fmt.Errorf("tx %d StateRootAfter (%s) does not chain into tx %d StateRootBefore (%s)", i-1, after.String(), i, before.String())
+		}
+	}
+	return BlockConstraints{
+		OldStateRoot: ws[0].StateRootBefore,
+		NewStateRoot: ws[len(ws)-1].StateRootAfter,
+		Txs:          ws,
+		TxsCount:     len(ws),
+	}, nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTxWitnesses(t *testing.T) {
+	rootA := []byte{1, 2, 3}
+	rootB := []byte{4, 5, 6}
+	rootC := []byte{7, 8, 9}
+
+	tx1, err := SetTxWitness(EmptyBlockTx(rootA))
+	require.NoError(t, err)
+	tx1.StateRootAfter = rootB
+
+	tx2, err := SetTxWitness(EmptyBlockTx(rootB))
+	require.NoError(t, err)
+	tx2.StateRootAfter = rootC
+
+	block, err := MergeTxWitnesses([]TxConstraints{tx1, tx2})
+	require.NoError(t, err)
+	require.Equal(t, 2, block.TxsCount)
+	require.Equal(t, rootA, block.OldStateRoot)
+	require.Equal(t, rootC, block.NewStateRoot)
+
+	_, err = MergeTxWitnesses(nil)
+	require.Error(t, err)
+
+	tx2Unchained, err := SetTxWitness(EmptyBlockTx(rootC))
+	require.NoError(t, err)
+	_, err = MergeTxWitnesses([]TxConstraints{tx1, tx2Unchained})
+	require.Error(t, err)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// StandardBlockSizes are the block sizes a BlockCircuitRegistry compiles by
+// default, from the one-tx circuit used for an otherwise-idle block up to
+// the largest batch this repo expects a sequencer to assemble. Sequencers
+// should pick the smallest of these that fits a given block's tx count
+// (via BlockCircuitRegistry.Get), since VerifyBlock's constraint count (and
+// so proving time) scales with TxsCount.
+var StandardBlockSizes = []int{1, 8, 16, 32, 64}
+
+// SmallestBlockSize returns the smallest size in sizes that is still >=
+// nbTxs, so a block with fewer transactions than the largest configured
+// size can still be proved by padding up to that size's circuit (e.g. with
+// EmptyBlockTx no-ops) rather than needing its own bespoke circuit. sizes
+// need not be pre-sorted. It errors if nbTxs exceeds every configured size.
+func SmallestBlockSize(sizes []int, nbTxs int) (int, error) {
+	best := -1
+	for _, size := range sizes {
+		if size >= nbTxs && (best == -1 || size < best) {
+			best = size
+		}
+	}
+	if best == -1 {
+		return 0, fmt.Errorf("no configured block size fits %d transactions", nbTxs)
+	}
+	return best, nil
+}
+
+// NewEmptyBlockCircuit builds a BlockConstraints value shaped for
+// frontend.Compile: its Txs slice has exactly txsCount placeholder entries
+// (GetZeroTxConstraint, the same zero tx already used by
+// witness_validation_test.go) and its Gas/GasAssetIds fields are sized for
+// gasAssetCount gas assets. Only the slice lengths matter for compilation;
+// the placeholder values themselves are never assigned as a witness.
+func NewEmptyBlockCircuit(txsCount, gasAssetCount int) *BlockConstraints {
+	circuit := &BlockConstraints{
+		Txs:             make([]TxConstraints, txsCount),
+		TxsCount:        txsCount,
+		GasAssetIds:     make([]int64, gasAssetCount),
+		GasAccountIndex: 0,
+		Gas: GasConstraints{
+			GasAssetCount: gasAssetCount,
+			AccountInfoBefore: GasAccountConstraints{
+				AssetsInfo:    make([]types.AccountAssetConstraints, gasAssetCount),
+				GasAssetCount: gasAssetCount,
+			},
+			MerkleProofsAccountAssetsBefore: make([][AssetMerkleLevels]Variable, gasAssetCount),
+		},
+	}
+	for i := 0; i < txsCount; i++ {
+		circuit.Txs[i] = GetZeroTxConstraint()
+	}
+	return circuit
+}
+
+// BlockCircuitRegistry holds one compiled constraint system per block size,
+// so a prover only pays circuit compilation once per size instead of once
+// per block. It is the "registry of compiled constraint systems per size"
+// VerifyBlock's variable-length Txs/TxsCount fields need in order to serve
+// more than one fixed block size from a single running process.
+type BlockCircuitRegistry struct {
+	sizes    []int
+	compiled map[int]frontend.CompiledConstraintSystem
+}
+
+// NewBlockCircuitRegistry compiles NewEmptyBlockCircuit(size, gasAssetCount)
+// for every size in sizes, so that every call afterwards is free of
+// recompilation. sizes is typically StandardBlockSizes.
+func NewBlockCircuitRegistry(sizes []int, gasAssetCount int) (*BlockCircuitRegistry, error) {
+	registry := &BlockCircuitRegistry{
+		sizes:    append([]int{}, sizes...),
+		compiled: make(map[int]frontend.CompiledConstraintSystem, len(sizes)),
+	}
+	sort.Ints(registry.sizes)
+	for _, size := range registry.sizes {
+		ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, NewEmptyBlockCircuit(size, gasAssetCount), frontend.IgnoreUnconstrainedInputs())
+		if err != nil {
+			return nil, fmt.Errorf("compile block circuit for size %d: %w", size, err)
+		}
+		registry.compiled[size] = ccs
+	}
+	return registry, nil
+}
+
+// Get returns the compiled constraint system for the smallest registered
+// size that still fits nbTxs transactions, along with that size.
+func (r *BlockCircuitRegistry) Get(nbTxs int) (frontend.CompiledConstraintSystem, int, error) {
+	size, err := SmallestBlockSize(r.sizes, nbTxs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.compiled[size], size, nil
+}
@@ -0,0 +1,54 @@
+package circuit
+
+import "testing"
+
+func TestSmallestBlockSizePicksSmallestFit(t *testing.T) {
+	sizes := []int{16, 1, 64, 8, 32}
+
+	testCases := []struct {
+		nbTxs    int
+		wantSize int
+	}{
+		{nbTxs: 1, wantSize: 1},
+		{nbTxs: 5, wantSize: 8},
+		{nbTxs: 8, wantSize: 8},
+		{nbTxs: 9, wantSize: 16},
+		{nbTxs: 64, wantSize: 64},
+	}
+	for _, tc := range testCases {
+		got, err := SmallestBlockSize(sizes, tc.nbTxs)
+		if err != nil {
+			t.Fatalf("SmallestBlockSize(%d) returned unexpected error: %v", tc.nbTxs, err)
+		}
+		if got != tc.wantSize {
+			t.Fatalf("SmallestBlockSize(%d) = %d, want %d", tc.nbTxs, got, tc.wantSize)
+		}
+	}
+
+	if _, err := SmallestBlockSize(sizes, 65); err == nil {
+		t.Fatalf("expected an error when nbTxs exceeds every configured size")
+	}
+}
+
+func TestNewEmptyBlockCircuitIsSizedForTxsAndGasAssets(t *testing.T) {
+	const txsCount = 8
+	const gasAssetCount = 2
+
+	circuit := NewEmptyBlockCircuit(txsCount, gasAssetCount)
+
+	if len(circuit.Txs) != txsCount {
+		t.Fatalf("len(Txs) = %d, want %d", len(circuit.Txs), txsCount)
+	}
+	if circuit.TxsCount != txsCount {
+		t.Fatalf("TxsCount = %d, want %d", circuit.TxsCount, txsCount)
+	}
+	if len(circuit.GasAssetIds) != gasAssetCount {
+		t.Fatalf("len(GasAssetIds) = %d, want %d", len(circuit.GasAssetIds), gasAssetCount)
+	}
+	if len(circuit.Gas.AccountInfoBefore.AssetsInfo) != gasAssetCount {
+		t.Fatalf("len(Gas.AccountInfoBefore.AssetsInfo) = %d, want %d", len(circuit.Gas.AccountInfoBefore.AssetsInfo), gasAssetCount)
+	}
+	if len(circuit.Gas.MerkleProofsAccountAssetsBefore) != gasAssetCount {
+		t.Fatalf("len(Gas.MerkleProofsAccountAssetsBefore) = %d, want %d", len(circuit.Gas.MerkleProofsAccountAssetsBefore), gasAssetCount)
+	}
+}
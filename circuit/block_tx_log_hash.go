@@ -0,0 +1,27 @@
+package circuit
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// BlockTxLogHash folds txIds into prevLogHash to produce the next running
+// hash of the sequencer's append-only transaction log: each txId is chained
+// in order via mimc(running, txId), starting from prevLogHash (or the zero
+// hash, for a block's first log entry). The result is order-sensitive: the
+// same txIds in a different order produce a different hash, matching the
+// fact that execution order is itself consensus-relevant.
+func BlockTxLogHash(txIds [][]byte, prevLogHash []byte) []byte {
+	running := big.NewInt(0)
+	if len(prevLogHash) > 0 {
+		running = new(big.Int).SetBytes(prevLogHash)
+	}
+	for _, txId := range txIds {
+		hFunc := mimc.NewMiMC()
+		hFunc.Write(running.FillBytes(make([]byte, 32)))
+		hFunc.Write(new(big.Int).SetBytes(txId).FillBytes(make([]byte, 32)))
+		running = new(big.Int).SetBytes(hFunc.Sum(nil))
+	}
+	return running.FillBytes(make([]byte, 32))
+}
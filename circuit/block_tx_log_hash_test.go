@@ -0,0 +1,31 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTxLogHashIsOrderSensitive(t *testing.T) {
+	txA := []byte{0x01, 0x02}
+	txB := []byte{0x03, 0x04}
+
+	forward := BlockTxLogHash([][]byte{txA, txB}, nil)
+	backward := BlockTxLogHash([][]byte{txB, txA}, nil)
+	require.NotEqual(t, forward, backward)
+}
+
+func TestBlockTxLogHashIsStable(t *testing.T) {
+	txA := []byte{0x01, 0x02}
+	txB := []byte{0x03, 0x04}
+
+	first := BlockTxLogHash([][]byte{txA, txB}, nil)
+	second := BlockTxLogHash([][]byte{txA, txB}, nil)
+	require.Equal(t, first, second)
+
+	// chaining from a previous block's log hash must depend on that prior
+	// value, not just the new block's own tx ids.
+	chained := BlockTxLogHash([][]byte{txB}, first)
+	fromScratch := BlockTxLogHash([][]byte{txB}, nil)
+	require.NotEqual(t, chained, fromScratch)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// EmptyBlockTx returns a no-op TxTypeEmptyTx transaction whose before and
+// after state roots both equal prevRoot, so a sequencer can advance the
+// chain's block height (e.g. to move time forward) without changing any
+// account, nft, or state root.
+func EmptyBlockTx(prevRoot []byte) *Tx {
+	tx := &Tx{
+		TxType:            uint8(types.TxTypeEmptyTx),
+		Signature:         types.EmptySignature(),
+		NftBefore:         types.EmptyNft(0),
+		AccountRootBefore: prevRoot,
+		NftRootBefore:     prevRoot,
+		StateRootBefore:   prevRoot,
+		StateRootAfter:    prevRoot,
+	}
+	for i := 0; i < NbAccountsPerTx; i++ {
+		tx.AccountsInfoBefore[i] = types.EmptyAccount(0, types.EmptyAssetRoot.Bytes())
+		for j := 0; j < AccountMerkleLevels; j++ {
+			tx.MerkleProofsAccountBefore[i][j] = make([]byte, 32)
+		}
+		for j := 0; j < NbAccountAssetsPerAccount; j++ {
+			for k := 0; k < AssetMerkleLevels; k++ {
+				tx.MerkleProofsAccountAssetsBefore[i][j][k] = make([]byte, 32)
+			}
+		}
+	}
+	for i := 0; i < NftMerkleLevels; i++ {
+		tx.MerkleProofsNftBefore[i] = make([]byte, 32)
+	}
+	return tx
+}
@@ -52,6 +52,7 @@ func VerifyGas(
 	accountRoot Variable) (newAccountRoot Variable, err error) {
 	newAccountRoot = accountRoot
 	newAccountAssetsRoot := gas.AccountInfoBefore.AssetRoot
+	merkleVerifier := types.NewMerkleVerifier(api, hFunc)
 
 	// check the existence of gas account
 	types.IsVariableDifferent(api, needGas, gas.AccountInfoBefore.AccountNameHash, types.ZeroInt)
@@ -66,24 +67,14 @@ func VerifyGas(
 		)
 		assetNodeHash := hFunc.Sum()
 		hFunc.Reset()
-		types.VerifyMerkleProof(
-			api,
-			needGas,
-			hFunc,
-			newAccountAssetsRoot,
-			assetNodeHash,
-			gas.MerkleProofsAccountAssetsBefore[i][:],
-			assetMerkleHelper,
-		)
-		hFunc.Reset()
 		hFunc.Write(
 			api.Add(gas.AccountInfoBefore.AssetsInfo[i].Balance, gasAssetDeltas[i]),
 			gas.AccountInfoBefore.AssetsInfo[i].OfferCanceledOrFinalized,
 		)
-		assetNodeHash = hFunc.Sum()
-		hFunc.Reset()
-		newAccountAssetsRoot = types.UpdateMerkleProof(
-			api, hFunc, assetNodeHash, gas.MerkleProofsAccountAssetsBefore[i][:], assetMerkleHelper)
+		newAssetNodeHash := hFunc.Sum()
+		newAccountAssetsRoot = merkleVerifier.VerifyAndUpdate(
+			needGas, newAccountAssetsRoot, assetNodeHash, newAssetNodeHash,
+			gas.MerkleProofsAccountAssetsBefore[i][:], assetMerkleHelper)
 	}
 	// verify account node hash
 	accountIndexMerkleHelper := AccountIndexToMerkleHelper(api, gas.AccountInfoBefore.AccountIndex)
@@ -97,17 +88,6 @@ func VerifyGas(
 		gas.AccountInfoBefore.AssetRoot,
 	)
 	accountNodeHash := hFunc.Sum()
-	// verify account merkle proof
-	hFunc.Reset()
-	types.VerifyMerkleProof(
-		api,
-		needGas,
-		hFunc,
-		newAccountRoot,
-		accountNodeHash,
-		gas.MerkleProofsAccountBefore[:],
-		accountIndexMerkleHelper,
-	)
 	hFunc.Reset()
 	hFunc.Write(
 		gas.AccountInfoBefore.AccountNameHash,
@@ -117,10 +97,11 @@ func VerifyGas(
 		gas.AccountInfoBefore.CollectionNonce,
 		newAccountAssetsRoot,
 	)
-	accountNodeHash = hFunc.Sum()
-	hFunc.Reset()
-	// update merkle proof
-	newAccountRoot = types.UpdateMerkleProof(api, hFunc, accountNodeHash, gas.MerkleProofsAccountBefore[:], accountIndexMerkleHelper)
+	newAccountNodeHash := hFunc.Sum()
+	// verify account merkle proof, then update it
+	newAccountRoot = merkleVerifier.VerifyAndUpdate(
+		needGas, newAccountRoot, accountNodeHash, newAccountNodeHash,
+		gas.MerkleProofsAccountBefore[:], accountIndexMerkleHelper)
 	return newAccountRoot, err
 }
 
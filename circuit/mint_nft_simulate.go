@@ -0,0 +1,31 @@
+package circuit
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// SimulateMintNftRoot computes the nft root a mint-nft tx would insert into:
+// types.VerifyMintNftTx (called from tx_constraints.go) checks the nft slot
+// at tx.NftIndex is empty before a mint, and VerifyTransaction then folds the
+// minted nft leaf into the tree at that same slot, so this is the native
+// twin of that insertion, built from the MintNftNode/NftNodeHash helpers in
+// types/mint_nft_node.go. nftMerkleProof is the minted slot's
+// NftMerkleLevels-deep Merkle proof, bottom-up, the same order
+// tx.MerkleProofsNftBefore is built in.
+func SimulateMintNftRoot(tx *types.MintNftTx, nftMerkleProof [][]byte) (newNftRoot []byte, err error) {
+	if tx == nil {
+		return nil, errors.New("tx should not be nil")
+	}
+	if len(nftMerkleProof) != NftMerkleLevels {
+		return nil, errors.New("nftMerkleProof has the wrong depth")
+	}
+
+	mintedNft := types.MintNftNode(tx)
+	nftLeafHash := types.NftNodeHash(mintedNft)
+	nftHelper := types.MerkleHelperFromIndex(tx.NftIndex, NftMerkleLevels)
+	newNftRoot = types.UpdateMerkleProofNative(nftLeafHash, nftMerkleProof, nftHelper)
+
+	return newNftRoot, nil
+}
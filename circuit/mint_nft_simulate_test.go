@@ -0,0 +1,44 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// TestSimulateMintNftRootMatchesCircuitInsertion checks that minting into an
+// (otherwise all-empty) nft tree produces the same root as a tree whose every
+// leaf but the minted one is the nil nft hash and the minted slot holds
+// MintNftNode(tx)'s leaf hash, i.e. that a witness builder using
+// SimulateMintNftRoot arrives at the same nft root the circuit does.
+func TestSimulateMintNftRootMatchesCircuitInsertion(t *testing.T) {
+	nilNftHash := types.NftNodeHash(types.EmptyNft(0))
+	nftZeroRoots := zeroSubtreeRoots(nilNftHash, NftMerkleLevels)
+	nftProof := make([][]byte, NftMerkleLevels)
+	for i := range nftProof {
+		nftProof[i] = nftZeroRoots[i]
+	}
+
+	tx := &types.MintNftTx{
+		CreatorAccountIndex: 1,
+		ToAccountIndex:      2,
+		NftIndex:            0,
+		NftContentHash:      []byte{0x04, 0x05, 0x06},
+		CreatorTreasuryRate: 200,
+		CollectionId:        5,
+	}
+
+	newNftRoot, err := SimulateMintNftRoot(tx, nftProof)
+	require.NoError(t, err)
+
+	mintedLeafHash := types.NftNodeHash(types.MintNftNode(tx))
+	require.Equal(t, types.UpdateMerkleProofNative(mintedLeafHash, nftProof, types.MerkleHelperFromIndex(tx.NftIndex, NftMerkleLevels)), newNftRoot)
+	require.NotEqual(t, nftZeroRoots[NftMerkleLevels], newNftRoot)
+
+	// A proof with the wrong depth is rejected rather than silently producing
+	// a bogus root.
+	_, err = SimulateMintNftRoot(tx, nftProof[:NftMerkleLevels-1])
+	require.Error(t, err)
+}
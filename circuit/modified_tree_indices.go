@@ -0,0 +1,202 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// ModifiedTreeIndices enumerates the account tree, asset tree, and nft tree
+// leaves txs writes to, for callers doing incremental state-DB updates
+// instead of replaying a whole block. accounts is the set of touched account
+// indices; assets maps each of those account indices to the asset ids its
+// balance changes reference; nfts is the set of touched nft indices. All
+// three are sorted for a stable, deterministic result.
+//
+// Every tx type is signed by AccountsInfoBefore[0] (see SignerPublicKey) and
+// so always touches that account; beyond that, which accounts, assets, and
+// nfts a tx touches is type-specific in the same way ReferencedAssetIds and
+// AccountNetFlow are, so this follows their per-TxType enumeration rather
+// than reading AccountsInfoBefore/NftBefore directly, since both are always
+// populated with placeholders (EmptyAccount, a zero Nft) for slots a given
+// tx type doesn't actually use.
+func ModifiedTreeIndices(txs []*Tx) (accounts []uint64, assets map[uint64][]uint64, nfts []uint64, err error) {
+	accountSet := make(map[uint64]bool)
+	assetSet := make(map[uint64]map[uint64]bool)
+	nftSet := make(map[uint64]bool)
+
+	addAccount := func(accountIndex int64) {
+		accountSet[uint64(accountIndex)] = true
+	}
+	addAsset := func(accountIndex, assetId int64) {
+		addAccount(accountIndex)
+		key := uint64(accountIndex)
+		if assetSet[key] == nil {
+			assetSet[key] = make(map[uint64]bool)
+		}
+		assetSet[key][uint64(assetId)] = true
+	}
+	addNft := func(nftIndex int64) {
+		nftSet[uint64(nftIndex)] = true
+	}
+
+	for i, tx := range txs {
+		if tx == nil {
+			return nil, nil, nil, fmt.Errorf("tx[%d] should not be nil", i)
+		}
+		if tx.AccountsInfoBefore[0] == nil {
+			return nil, nil, nil, fmt.Errorf("tx[%d]: AccountsInfoBefore[0] should not be nil", i)
+		}
+		addAccount(tx.AccountsInfoBefore[0].AccountIndex)
+
+		switch tx.TxType {
+		case types.TxTypeRegisterZns:
+			if tx.RegisterZnsTxInfo == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: RegisterZnsTxInfo should not be nil", i)
+			}
+			addAccount(tx.RegisterZnsTxInfo.AccountIndex)
+		case types.TxTypeDeposit:
+			info := tx.DepositTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: DepositTxInfo should not be nil", i)
+			}
+			addAsset(info.AccountIndex, info.AssetId)
+		case types.TxTypeDepositNft:
+			info := tx.DepositNftTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: DepositNftTxInfo should not be nil", i)
+			}
+			addAccount(info.AccountIndex)
+			addNft(info.NftIndex)
+		case types.TxTypeTransfer:
+			info := tx.TransferTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: TransferTxInfo should not be nil", i)
+			}
+			addAsset(info.FromAccountIndex, info.AssetId)
+			addAsset(info.FromAccountIndex, info.GasFeeAssetId)
+			addAsset(info.ToAccountIndex, info.AssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+		case types.TxTypeCreateCollection:
+			info := tx.CreateCollectionTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: CreateCollectionTxInfo should not be nil", i)
+			}
+			addAsset(info.AccountIndex, info.GasFeeAssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+		case types.TxTypeMintNft:
+			info := tx.MintNftTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: MintNftTxInfo should not be nil", i)
+			}
+			addAsset(info.CreatorAccountIndex, info.GasFeeAssetId)
+			addAccount(info.ToAccountIndex)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+			addNft(info.NftIndex)
+		case types.TxTypeTransferNft:
+			info := tx.TransferNftTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: TransferNftTxInfo should not be nil", i)
+			}
+			addAsset(info.FromAccountIndex, info.GasFeeAssetId)
+			addAccount(info.ToAccountIndex)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+			addNft(info.NftIndex)
+		case types.TxTypeAtomicMatch:
+			info := tx.AtomicMatchTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: AtomicMatchTxInfo should not be nil", i)
+			}
+			if info.BuyOffer == nil || info.SellOffer == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: AtomicMatchTxInfo offers should not be nil", i)
+			}
+			addAsset(info.BuyOffer.AccountIndex, info.BuyOffer.AssetId)
+			addAsset(info.SellOffer.AccountIndex, info.BuyOffer.AssetId)
+			if tx.NftBefore != nil {
+				addAsset(tx.NftBefore.CreatorAccountIndex, info.BuyOffer.AssetId)
+			}
+			addAsset(info.AccountIndex, info.GasFeeAssetId)
+			addAsset(info.GasAccountIndex, info.BuyOffer.AssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+			addNft(info.BuyOffer.NftIndex)
+		case types.TxTypeCancelOffer:
+			info := tx.CancelOfferTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: CancelOfferTxInfo should not be nil", i)
+			}
+			addAsset(info.AccountIndex, info.GasFeeAssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+		case types.TxTypeWithdraw:
+			info := tx.WithdrawTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: WithdrawTxInfo should not be nil", i)
+			}
+			addAsset(info.FromAccountIndex, info.AssetId)
+			addAsset(info.FromAccountIndex, info.GasFeeAssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+		case types.TxTypeWithdrawNft:
+			info := tx.WithdrawNftTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: WithdrawNftTxInfo should not be nil", i)
+			}
+			addAsset(info.AccountIndex, info.GasFeeAssetId)
+			addAsset(info.GasAccountIndex, info.GasFeeAssetId)
+			addNft(info.NftIndex)
+		case types.TxTypeFullExit:
+			info := tx.FullExitTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: FullExitTxInfo should not be nil", i)
+			}
+			addAsset(info.AccountIndex, info.AssetId)
+		case types.TxTypeFullExitNft:
+			info := tx.FullExitNftTxInfo
+			if info == nil {
+				return nil, nil, nil, fmt.Errorf("tx[%d]: FullExitNftTxInfo should not be nil", i)
+			}
+			addAccount(info.AccountIndex)
+			addNft(info.NftIndex)
+		}
+	}
+
+	accounts = make([]uint64, 0, len(accountSet))
+	for accountIndex := range accountSet {
+		accounts = append(accounts, accountIndex)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i] < accounts[j] })
+
+	assets = make(map[uint64][]uint64, len(assetSet))
+	for accountIndex, assetIds := range assetSet {
+		ids := make([]uint64, 0, len(assetIds))
+		for assetId := range assetIds {
+			ids = append(ids, assetId)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		assets[accountIndex] = ids
+	}
+
+	nfts = make([]uint64, 0, len(nftSet))
+	for nftIndex := range nftSet {
+		nfts = append(nfts, nftIndex)
+	}
+	sort.Slice(nfts, func(i, j int) bool { return nfts[i] < nfts[j] })
+
+	return accounts, assets, nfts, nil
+}
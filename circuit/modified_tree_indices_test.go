@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func TestModifiedTreeIndicesOverMixedBlock(t *testing.T) {
+	transfer := &Tx{
+		TxType: uint8(types.TxTypeTransfer),
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{
+			{AccountIndex: 1},
+		},
+		TransferTxInfo: &TransferTx{
+			FromAccountIndex:  1,
+			ToAccountIndex:    2,
+			AssetId:           3,
+			AssetAmount:       100,
+			GasAccountIndex:   9,
+			GasFeeAssetId:     0,
+			GasFeeAssetAmount: 1,
+		},
+	}
+	mintNft := &Tx{
+		TxType: uint8(types.TxTypeMintNft),
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{
+			{AccountIndex: 2},
+		},
+		MintNftTxInfo: &MintNftTx{
+			CreatorAccountIndex: 2,
+			ToAccountIndex:      4,
+			NftIndex:            7,
+			GasAccountIndex:     9,
+			GasFeeAssetId:       0,
+			GasFeeAssetAmount:   1,
+		},
+	}
+
+	accounts, assets, nfts, err := ModifiedTreeIndices([]*Tx{transfer, mintNft})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 4, 9}, accounts)
+	require.Equal(t, []uint64{7}, nfts)
+	require.Equal(t, []uint64{0, 3}, assets[1])
+	require.Equal(t, []uint64{0, 3}, assets[2])
+	require.Equal(t, []uint64{0}, assets[9])
+	require.Nil(t, assets[4])
+
+	_, _, _, err = ModifiedTreeIndices([]*Tx{nil})
+	require.Error(t, err)
+
+	_, _, _, err = ModifiedTreeIndices([]*Tx{{TxType: uint8(types.TxTypeTransfer)}})
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package circuit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// ReferencedAssetIds returns the distinct asset ids referenced anywhere
+// across txs: both the gas fee asset paid on signature-bearing tx types and
+// the traded asset on tx types that move one (deposit, withdraw, transfer,
+// full exit, atomic match). Tx types with neither (RegisterZns,
+// CreateCollection beyond its gas fee, *Nft tx types beyond their gas fee)
+// contribute only what they actually reference. The result is sorted so
+// callers get a stable prefetch order.
+func ReferencedAssetIds(txs []*Tx) ([]int64, error) {
+	seen := make(map[int64]bool)
+	add := func(assetId int64) {
+		seen[assetId] = true
+	}
+
+	for i, tx := range txs {
+		if tx == nil {
+			return nil, fmt.Errorf("tx[%d] should not be nil", i)
+		}
+		switch tx.TxType {
+		case types.TxTypeDeposit:
+			if tx.DepositTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: DepositTxInfo should not be nil", i)
+			}
+			add(tx.DepositTxInfo.AssetId)
+		case types.TxTypeTransfer:
+			if tx.TransferTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: TransferTxInfo should not be nil", i)
+			}
+			add(tx.TransferTxInfo.AssetId)
+			add(tx.TransferTxInfo.GasFeeAssetId)
+		case types.TxTypeWithdraw:
+			if tx.WithdrawTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: WithdrawTxInfo should not be nil", i)
+			}
+			add(tx.WithdrawTxInfo.AssetId)
+			add(tx.WithdrawTxInfo.GasFeeAssetId)
+		case types.TxTypeCreateCollection:
+			if tx.CreateCollectionTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: CreateCollectionTxInfo should not be nil", i)
+			}
+			add(tx.CreateCollectionTxInfo.GasFeeAssetId)
+		case types.TxTypeMintNft:
+			if tx.MintNftTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: MintNftTxInfo should not be nil", i)
+			}
+			add(tx.MintNftTxInfo.GasFeeAssetId)
+		case types.TxTypeTransferNft:
+			if tx.TransferNftTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: TransferNftTxInfo should not be nil", i)
+			}
+			add(tx.TransferNftTxInfo.GasFeeAssetId)
+		case types.TxTypeAtomicMatch:
+			if tx.AtomicMatchTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: AtomicMatchTxInfo should not be nil", i)
+			}
+			if tx.AtomicMatchTxInfo.BuyOffer != nil {
+				add(tx.AtomicMatchTxInfo.BuyOffer.AssetId)
+			}
+			add(tx.AtomicMatchTxInfo.GasFeeAssetId)
+		case types.TxTypeCancelOffer:
+			if tx.CancelOfferTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: CancelOfferTxInfo should not be nil", i)
+			}
+			add(tx.CancelOfferTxInfo.GasFeeAssetId)
+		case types.TxTypeWithdrawNft:
+			if tx.WithdrawNftTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: WithdrawNftTxInfo should not be nil", i)
+			}
+			add(tx.WithdrawNftTxInfo.GasFeeAssetId)
+		case types.TxTypeFullExit:
+			if tx.FullExitTxInfo == nil {
+				return nil, fmt.Errorf("tx[%d]: FullExitTxInfo should not be nil", i)
+			}
+			add(tx.FullExitTxInfo.AssetId)
+		}
+	}
+
+	assetIds := make([]int64, 0, len(seen))
+	for assetId := range seen {
+		assetIds = append(assetIds, assetId)
+	}
+	sort.Slice(assetIds, func(i, j int) bool { return assetIds[i] < assetIds[j] })
+	return assetIds, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func TestReferencedAssetIds(t *testing.T) {
+	deposit := &Tx{
+		TxType: uint8(types.TxTypeDeposit),
+		DepositTxInfo: &DepositTx{
+			AccountIndex: 1,
+			AssetId:      0,
+			AssetAmount:  big.NewInt(100),
+		},
+	}
+	transfer := &Tx{
+		TxType: uint8(types.TxTypeTransfer),
+		TransferTxInfo: &TransferTx{
+			FromAccountIndex:  1,
+			ToAccountIndex:    2,
+			AssetId:           3,
+			AssetAmount:       100,
+			GasAccountIndex:   9,
+			GasFeeAssetId:     1,
+			GasFeeAssetAmount: 10,
+		},
+	}
+	swap := &Tx{
+		TxType: uint8(types.TxTypeAtomicMatch),
+		AtomicMatchTxInfo: &AtomicMatchTx{
+			AccountIndex: 2,
+			BuyOffer: &types.OfferTx{
+				AccountIndex: 2,
+				AssetId:      5,
+				AssetAmount:  500,
+			},
+			SellOffer: &types.OfferTx{
+				AccountIndex: 4,
+				AssetId:      5,
+				AssetAmount:  500,
+			},
+			GasAccountIndex:   9,
+			GasFeeAssetId:     1,
+			GasFeeAssetAmount: 5,
+		},
+	}
+	mintNft := &Tx{
+		TxType: uint8(types.TxTypeMintNft),
+		MintNftTxInfo: &MintNftTx{
+			CreatorAccountIndex: 2,
+			ToAccountIndex:      4,
+			GasAccountIndex:     9,
+			GasFeeAssetId:       0,
+			GasFeeAssetAmount:   1,
+		},
+	}
+
+	assetIds, err := ReferencedAssetIds([]*Tx{deposit, transfer, swap, mintNft})
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, 3, 5}, assetIds)
+
+	_, err = ReferencedAssetIds([]*Tx{nil})
+	require.Error(t, err)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// floorDivRateBase constrains quotient = floor(numerator/RateBase), using a
+// hint to obtain the witness and a range-checked remainder to prove the
+// division is exact over the integers rather than merely over the scalar
+// field (which is what a bare api.Div would give).
+func floorDivRateBase(api API, numerator Variable) (quotient Variable) {
+	outputs, _ := api.Compiler().NewHint(types.DivFloor, 2, numerator, RateBase)
+	quotient, remainder := outputs[0], outputs[1]
+	api.AssertIsLessOrEqual(remainder, RateBase-1)
+	api.AssertIsEqual(api.Add(api.Mul(quotient, RateBase), remainder), numerator)
+	return quotient
+}
+
+// SplitSalePrice is the in-circuit counterpart of
+// types.SplitSalePriceNative: it splits price into the creator's treasury
+// share at rate (out of RateBase) and the remainder owed to the seller,
+// enforcing the same floor-to-creator rounding.
+func SplitSalePrice(api API, price, rate Variable) (toCreator, toSeller Variable) {
+	toCreator = floorDivRateBase(api, api.Mul(price, rate))
+	toSeller = api.Sub(price, toCreator)
+	return toCreator, toSeller
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+var splitSalePriceProverOpts = backend.WithHints(types.DivFloor)
+
+type splitSalePriceConstraints struct {
+	Price         Variable
+	Rate          Variable
+	ExpectCreator Variable
+	ExpectSeller  Variable
+}
+
+func (circuit splitSalePriceConstraints) Define(api API) error {
+	toCreator, toSeller := SplitSalePrice(api, circuit.Price, circuit.Rate)
+	api.AssertIsEqual(toCreator, circuit.ExpectCreator)
+	api.AssertIsEqual(toSeller, circuit.ExpectSeller)
+	return nil
+}
+
+func testSplitSalePriceParity(t *testing.T, price *big.Int, rate int64) {
+	toCreator, toSeller := types.SplitSalePriceNative(price, rate)
+
+	assert := test.NewAssert(t)
+	var circuit splitSalePriceConstraints
+	witness := splitSalePriceConstraints{
+		Price:         price,
+		Rate:          rate,
+		ExpectCreator: toCreator,
+		ExpectSeller:  toSeller,
+	}
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16),
+		test.WithProverOpts(splitSalePriceProverOpts),
+		test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+// TestSplitSalePriceExact checks a split where price*rate is evenly
+// divisible by RateBase.
+func TestSplitSalePriceExact(t *testing.T) {
+	testSplitSalePriceParity(t, big.NewInt(1000), 2000)
+}
+
+// TestSplitSalePriceRoundsDown checks a split where price*rate is not
+// evenly divisible by RateBase, confirming the circuit rounds down to the creator
+// exactly like SplitSalePriceNative.
+func TestSplitSalePriceRoundsDown(t *testing.T) {
+	testSplitSalePriceParity(t, big.NewInt(7), 2000)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ExtractBlockNumber decodes the block number out of a public input vector,
+// assuming the circuit follows the convention of exposing it as its first
+// public input, ahead of any other public outputs such as the state roots
+// ExtractStateRoots reads. This lets a verifier confirm a proof corresponds
+// to a specific block before accepting it, without re-deriving the block
+// commitment.
+func ExtractBlockNumber(publicInputs []fr.Element) (int64, error) {
+	if len(publicInputs) < 1 {
+		return 0, fmt.Errorf("public inputs should contain at least 1 element (block number), got %d", len(publicInputs))
+	}
+	blockNumber := publicInputs[0].ToBigIntRegular(new(big.Int))
+	if !blockNumber.IsInt64() {
+		return 0, fmt.Errorf("block number public input does not fit in an int64")
+	}
+	return blockNumber.Int64(), nil
+}
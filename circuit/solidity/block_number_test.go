@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// blockNumberCircuit is a minimal stand-in for a circuit that exposes the
+// block number as its first public input.
+type blockNumberCircuit struct {
+	BlockNumber frontend.Variable `gnark:",public"`
+	Nonce       frontend.Variable
+}
+
+func (c *blockNumberCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Nonce, c.Nonce)
+	return nil
+}
+
+func TestExtractBlockNumberRoundTrip(t *testing.T) {
+	assignment := &blockNumberCircuit{
+		BlockNumber: 123456,
+		Nonce:       1,
+	}
+
+	publicInputs, err := PublicInputs(assignment)
+	require.NoError(t, err)
+	require.Len(t, publicInputs, 1)
+
+	blockNumber, err := ExtractBlockNumber(publicInputs)
+	require.NoError(t, err)
+	require.Equal(t, int64(123456), blockNumber)
+}
+
+func TestExtractBlockNumberRejectsEmptyInput(t *testing.T) {
+	_, err := ExtractBlockNumber(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit"
+)
+
+func TestEmptyBlockTxProvesAndPreservesRoot(t *testing.T) {
+	prevRoot := make([]byte, 32)
+	for i := range prevRoot {
+		prevRoot[i] = byte(i + 1)
+	}
+
+	tx := circuit.EmptyBlockTx(prevRoot)
+	require.Equal(t, prevRoot, tx.StateRootBefore)
+	require.Equal(t, prevRoot, tx.StateRootAfter)
+
+	witness, err := circuit.SetTxWitness(tx)
+	require.NoError(t, err)
+
+	var txCircuit circuit.TxConstraints
+	proof, vk, err := Prove(Groth16Backend, &txCircuit, &witness)
+	require.NoError(t, err)
+	require.NoError(t, Verify(Groth16Backend, proof, vk, &witness))
+}
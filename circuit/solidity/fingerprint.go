@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+)
+
+// CircuitFingerprint compiles circuit for BN254/R1CS, the same way Prove's
+// Groth16Backend case does, and returns a stable hex-encoded SHA-256 digest
+// of its serialized constraint system. Two circuit values produce the same
+// fingerprint if and only if they compile to the same R1CS structure, so
+// this can key a compiled-circuit cache or check a stored proving/verifying
+// key pair is still compatible with the circuit it was set up against,
+// without re-running setup just to find out it drifted.
+func CircuitFingerprint(circuit frontend.Circuit) (string, error) {
+	ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, circuit, frontend.IgnoreUnconstrainedInputs())
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if _, err := ccs.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// fingerprintCircuit is a minimal toy circuit whose constraint count
+// TestCircuitFingerprintChangesWithConstraints varies by toggling
+// ExtraConstraint, to check CircuitFingerprint actually reflects the
+// compiled R1CS rather than, say, just the circuit's Go type.
+type fingerprintCircuit struct {
+	A, B, C         frontend.Variable
+	ExtraConstraint bool `gnark:"-"`
+}
+
+func (circuit *fingerprintCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Add(circuit.A, circuit.B), circuit.C)
+	if circuit.ExtraConstraint {
+		api.AssertIsEqual(circuit.A, circuit.A)
+	}
+	return nil
+}
+
+func TestCircuitFingerprintChangesWithConstraints(t *testing.T) {
+	base := &fingerprintCircuit{}
+	withExtra := &fingerprintCircuit{ExtraConstraint: true}
+
+	baseFingerprint, err := CircuitFingerprint(base)
+	require.NoError(t, err)
+	require.NotEmpty(t, baseFingerprint)
+
+	extraFingerprint, err := CircuitFingerprint(withExtra)
+	require.NoError(t, err)
+	require.NotEqual(t, baseFingerprint, extraFingerprint)
+
+	// compiling the same circuit twice is deterministic
+	baseFingerprintAgain, err := CircuitFingerprint(&fingerprintCircuit{})
+	require.NoError(t, err)
+	require.Equal(t, baseFingerprint, baseFingerprintAgain)
+}
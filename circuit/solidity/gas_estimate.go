@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+// GasCalibration holds the per-operation gas costs used to estimate the cost of
+// verifying a Groth16 proof on-chain. The defaults approximate the costs of the
+// BN254 precompiles (ecAdd, ecMul, ecPairing) plus the fixed overhead of the
+// zkBNB verifier contract, and can be overridden to match a specific deployment.
+type GasCalibration struct {
+	// BaseGas is the fixed cost of the verifier call, independent of the number
+	// of public inputs (proof deserialization, pairing setup, etc).
+	BaseGas uint64
+	// PerPublicInputGas is the marginal cost of folding one additional public
+	// input into the verification key's linear combination (an ecMul + ecAdd).
+	PerPublicInputGas uint64
+	// PairingGas is the cost of the final pairing check, which Groth16
+	// verification always performs exactly once regardless of input count.
+	PairingGas uint64
+}
+
+// DefaultGasCalibration is calibrated against the BN254 precompile gas costs
+// defined by EIP-196/EIP-197.
+var DefaultGasCalibration = GasCalibration{
+	BaseGas:           45000,
+	PerPublicInputGas: 6000,
+	PairingGas:        113000,
+}
+
+// EstimateVerificationGas estimates the L1 gas cost of verifying a Groth16 proof
+// with nbPublicInputs public inputs, using the default calibration.
+func EstimateVerificationGas(nbPublicInputs int) uint64 {
+	return DefaultGasCalibration.Estimate(nbPublicInputs)
+}
+
+// Estimate computes the estimated verification gas for nbPublicInputs public
+// inputs under this calibration.
+func (c GasCalibration) Estimate(nbPublicInputs int) uint64 {
+	if nbPublicInputs < 0 {
+		nbPublicInputs = 0
+	}
+	return c.BaseGas + c.PairingGas + uint64(nbPublicInputs)*c.PerPublicInputGas
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import "testing"
+
+func TestEstimateVerificationGas(t *testing.T) {
+	gas := EstimateVerificationGas(10)
+	if gas == 0 {
+		t.Fatalf("expected a positive gas estimate, got 0")
+	}
+
+	more := EstimateVerificationGas(20)
+	if more <= gas {
+		t.Fatalf("expected gas estimate to grow with public input count: %d vs %d", more, gas)
+	}
+
+	custom := GasCalibration{BaseGas: 1000, PerPublicInputGas: 100, PairingGas: 2000}
+	if got, want := custom.Estimate(3), uint64(1000+2000+3*100); got != want {
+		t.Fatalf("Estimate(3) = %d, want %d", got, want)
+	}
+}
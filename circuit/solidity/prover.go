@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzgbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+// ProverBackend selects which zkSNARK backend a circuit is compiled, set up
+// and proved against. Deployments pick Groth16Backend for its smaller,
+// fixed-size on-chain verification cost, or PlonkBackend for its universal
+// (circuit-independent) trusted setup.
+type ProverBackend int
+
+const (
+	Groth16Backend ProverBackend = iota
+	PlonkBackend
+)
+
+// Proof is the subset of the groth16.Proof / plonk.Proof interfaces that
+// callers of Prove actually need: both kinds of proof know how to serialize
+// themselves, which is all that is required to hand a proof off to Verify or
+// ship it to an on-chain verifier.
+type Proof interface {
+	io.WriterTo
+}
+
+// VerifyingKey is the common surface of groth16.VerifyingKey and
+// plonk.VerifyingKey that Prove returns alongside a Proof.
+type VerifyingKey interface {
+	io.WriterTo
+}
+
+// Prove compiles circuit for the selected backend, runs a fresh (insecure)
+// setup against it, and proves assignment. It returns the resulting Proof
+// together with the VerifyingKey the setup produced, so the caller can
+// immediately pass both into Verify. Prove always performs its own setup;
+// callers that need to persist and reuse a proving/verifying key pair across
+// many proofs should call the backend's own Compile/Setup instead.
+func Prove(proverBackend ProverBackend, circuit, assignment frontend.Circuit) (Proof, VerifyingKey, error) {
+	switch proverBackend {
+	case Groth16Backend:
+		ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, circuit, frontend.IgnoreUnconstrainedInputs())
+		if err != nil {
+			return nil, nil, err
+		}
+		pk, vk, err := groth16.Setup(ccs)
+		if err != nil {
+			return nil, nil, err
+		}
+		fullWitness, err := frontend.NewWitness(assignment, ecc.BN254)
+		if err != nil {
+			return nil, nil, err
+		}
+		proof, err := groth16.Prove(ccs, pk, fullWitness)
+		if err != nil {
+			return nil, nil, err
+		}
+		return proof, vk, nil
+	case PlonkBackend:
+		ccs, err := frontend.Compile(ecc.BN254, scs.NewBuilder, circuit, frontend.IgnoreUnconstrainedInputs())
+		if err != nil {
+			return nil, nil, err
+		}
+		srs, err := kzgbn254.NewSRS(ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints()))+3, big.NewInt(42))
+		if err != nil {
+			return nil, nil, err
+		}
+		pk, vk, err := plonk.Setup(ccs, srs)
+		if err != nil {
+			return nil, nil, err
+		}
+		fullWitness, err := frontend.NewWitness(assignment, ecc.BN254)
+		if err != nil {
+			return nil, nil, err
+		}
+		proof, err := plonk.Prove(ccs, pk, fullWitness)
+		if err != nil {
+			return nil, nil, err
+		}
+		return proof, vk, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported prover backend: %d", proverBackend)
+	}
+}
+
+// VerifyIndependentProofs verifies a batch of proofs that were generated
+// independently of one another (as opposed to being chained into a single
+// block, where each proof's public input commits to the previous one). Every
+// proof is checked against the same vk and proverBackend but its own
+// assignment, and proofs[i] is verified regardless of whether proofs[j]
+// failed: the returned errs slice has one entry per proof, nil where
+// verification succeeded, so a caller can tell which proofs in the batch were
+// bad instead of only learning that the batch as a whole was not all good.
+// The second return value reports a batch-level problem, such as proofs and
+// assignments having different lengths, that prevented verification from
+// running at all.
+func VerifyIndependentProofs(proverBackend ProverBackend, vk VerifyingKey, proofs []Proof, assignments []frontend.Circuit) ([]error, error) {
+	if len(proofs) != len(assignments) {
+		return nil, fmt.Errorf("proofs and assignments should have the same length, got %d and %d", len(proofs), len(assignments))
+	}
+	errs := make([]error, len(proofs))
+	for i, proof := range proofs {
+		errs[i] = Verify(proverBackend, proof, vk, assignments[i])
+	}
+	return errs, nil
+}
+
+// Verify checks proof against vk and the public inputs of assignment, using
+// the same backend that produced them.
+func Verify(proverBackend ProverBackend, proof Proof, vk VerifyingKey, assignment frontend.Circuit) error {
+	publicWitness, err := frontend.NewWitness(assignment, ecc.BN254, frontend.PublicOnly())
+	if err != nil {
+		return err
+	}
+	switch proverBackend {
+	case Groth16Backend:
+		return groth16.Verify(proof.(groth16.Proof), vk.(groth16.VerifyingKey), publicWitness)
+	case PlonkBackend:
+		return plonk.Verify(proof.(plonk.Proof), vk.(plonk.VerifyingKey), publicWitness)
+	default:
+		return fmt.Errorf("unsupported prover backend: %d", proverBackend)
+	}
+}
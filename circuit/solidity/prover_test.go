@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit"
+)
+
+func TestProveAndVerifyPerBackend(t *testing.T) {
+	backends := []ProverBackend{Groth16Backend, PlonkBackend}
+	for _, proverBackend := range backends {
+		circuitAssignment := circuit.GetZeroTxConstraint()
+		witnessAssignment := circuit.GetZeroTxConstraint()
+
+		proof, vk, err := Prove(proverBackend, &circuitAssignment, &witnessAssignment)
+		require.NoError(t, err)
+
+		err = Verify(proverBackend, proof, vk, &witnessAssignment)
+		require.NoError(t, err)
+	}
+}
+
+func TestVerifyIndependentProofs(t *testing.T) {
+	circuitAssignment := circuit.GetZeroTxConstraint()
+	validWitness := circuit.GetZeroTxConstraint()
+
+	validProof, vk, err := Prove(Groth16Backend, &circuitAssignment, &validWitness)
+	require.NoError(t, err)
+
+	// a second, independently generated proof against the same circuit
+	otherWitness := circuit.GetZeroTxConstraint()
+	otherProof, otherVk, err := Prove(Groth16Backend, &circuitAssignment, &otherWitness)
+	require.NoError(t, err)
+
+	// tampered: verify otherProof against vk from a different setup so it does not check out
+	errs, err := VerifyIndependentProofs(
+		Groth16Backend,
+		vk,
+		[]Proof{validProof, otherProof},
+		[]frontend.Circuit{&validWitness, &otherWitness},
+	)
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.NotNil(t, otherVk)
+
+	// a length mismatch between proofs and assignments is reported as a batch-level error
+	_, err = VerifyIndependentProofs(Groth16Backend, vk, []Proof{validProof}, []frontend.Circuit{&validWitness, &otherWitness})
+	require.Error(t, err)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// RecursionPublicInputs captures the commitment chain a future recursive
+// aggregation circuit would expose as its public inputs: the aggregated
+// commitment the batch being folded in built on top of, and the aggregated
+// commitment after folding it in. There is no recursive circuit in this
+// repo yet to verify such a chain in-circuit; BuildRecursionInputs is a
+// native scaffold so the data shape that circuit will need to agree on can
+// be settled ahead of it.
+type RecursionPublicInputs struct {
+	PrevCommitment []byte
+	NextCommitment []byte
+}
+
+// BuildRecursionInputs folds blockProofs into a commitment chain: each
+// proof's serialized bytes are hashed together with the running commitment,
+// in order, so PrevCommitment is the commitment before the last proof was
+// folded in (the zero value for a single-proof batch) and NextCommitment is
+// the commitment after all of blockProofs have been folded in. A real
+// recursive circuit would instead verify each block proof and accumulate a
+// circuit-native commitment in-circuit; this sha256-based chain is only a
+// placeholder standing in for that accumulator.
+func BuildRecursionInputs(blockProofs []Proof) (RecursionPublicInputs, error) {
+	if len(blockProofs) == 0 {
+		return RecursionPublicInputs{}, fmt.Errorf("blockProofs should not be empty")
+	}
+
+	var prevCommitment, commitment []byte
+	for i, proof := range blockProofs {
+		if proof == nil {
+			return RecursionPublicInputs{}, fmt.Errorf("blockProofs[%d] should not be nil", i)
+		}
+		var buf bytes.Buffer
+		if _, err := proof.WriteTo(&buf); err != nil {
+			return RecursionPublicInputs{}, err
+		}
+		prevCommitment = commitment
+		sum := sha256.Sum256(append(append([]byte{}, commitment...), buf.Bytes()...))
+		commitment = sum[:]
+	}
+
+	return RecursionPublicInputs{
+		PrevCommitment: prevCommitment,
+		NextCommitment: commitment,
+	}, nil
+}
+
+// AggregatedProof is the native result of AggregateBlockProofs: the
+// commitment chain BuildRecursionInputs produces, plus how many block
+// proofs were folded into it.
+type AggregatedProof struct {
+	RecursionPublicInputs
+	NbProofs int
+}
+
+// AggregateBlockProofs verifies every one of blockProofs against vk and its
+// own assignment (so the commitment chain it returns is only ever built
+// from block proofs that actually checked out), then folds them into a
+// commitment chain via BuildRecursionInputs.
+//
+// This is not the in-circuit BN254 recursive verifier a real aggregation
+// circuit would need to let one proof attest to many blocks on L1: gnark
+// v0.7.0, what this repo is pinned to, only ships in-circuit Groth16
+// verifier gadgets for the BLS12-377-in-BW6-761 and BLS24-315-in-BW6-633
+// curve cycles (std/groth16_bls12377, std/groth16_bls24315). There is no
+// BN254-native pairing cycle support in this dependency tree to verify a
+// BN254 proof inside another BN254 circuit, so a from-scratch pairing-based
+// verifier gadget here would be unreviewed and have no reference
+// implementation to check it against. AggregateBlockProofs is the
+// native-only step such a circuit would still need regardless (rejecting
+// any bad proof before it is folded into the aggregate); it is left here so
+// the commitment chain it produces is ready for an in-circuit verifier to
+// consume once this repo's curve support allows one.
+func AggregateBlockProofs(proverBackend ProverBackend, vk VerifyingKey, blockProofs []Proof, assignments []frontend.Circuit) (AggregatedProof, error) {
+	errs, err := VerifyIndependentProofs(proverBackend, vk, blockProofs, assignments)
+	if err != nil {
+		return AggregatedProof{}, err
+	}
+	for i, verifyErr := range errs {
+		if verifyErr != nil {
+			return AggregatedProof{}, fmt.Errorf("blockProofs[%d] failed verification: %w", i, verifyErr)
+		}
+	}
+
+	inputs, err := BuildRecursionInputs(blockProofs)
+	if err != nil {
+		return AggregatedProof{}, err
+	}
+	return AggregatedProof{RecursionPublicInputs: inputs, NbProofs: len(blockProofs)}, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRecursionInputsOverTwoBlockProofs(t *testing.T) {
+	circuitAssignment := fingerprintCircuit{}
+	firstWitness := fingerprintCircuit{A: 1, B: 2, C: 3}
+	secondWitness := fingerprintCircuit{A: 4, B: 5, C: 9}
+
+	firstProof, _, err := Prove(Groth16Backend, &circuitAssignment, &firstWitness)
+	require.NoError(t, err)
+	secondProof, _, err := Prove(Groth16Backend, &circuitAssignment, &secondWitness)
+	require.NoError(t, err)
+
+	// folding in just the first proof should land on the prev commitment of
+	// the two-proof chain, confirming the chain is built incrementally.
+	firstOnly, err := BuildRecursionInputs([]Proof{firstProof})
+	require.NoError(t, err)
+	require.Empty(t, firstOnly.PrevCommitment)
+	require.NotEmpty(t, firstOnly.NextCommitment)
+
+	inputs, err := BuildRecursionInputs([]Proof{firstProof, secondProof})
+	require.NoError(t, err)
+	require.Equal(t, firstOnly.NextCommitment, inputs.PrevCommitment)
+	require.NotEmpty(t, inputs.NextCommitment)
+	require.NotEqual(t, inputs.PrevCommitment, inputs.NextCommitment)
+
+	_, err = BuildRecursionInputs(nil)
+	require.Error(t, err)
+	_, err = BuildRecursionInputs([]Proof{nil})
+	require.Error(t, err)
+}
+
+// publicCommitmentCircuit is a minimal toy circuit with a public input, so
+// a proof/assignment mismatch can actually be told apart: fingerprintCircuit
+// (used elsewhere in this package) has none, so any valid proof from its
+// ccs/pk verifies against any assignment's (empty) public witness.
+type publicCommitmentCircuit struct {
+	Commitment frontend.Variable `gnark:",public"`
+	Nonce      frontend.Variable
+}
+
+func (c *publicCommitmentCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Commitment, api.Add(c.Nonce, c.Nonce))
+	return nil
+}
+
+func TestAggregateBlockProofsFoldsOnlyWhatVerifies(t *testing.T) {
+	circuitAssignment := publicCommitmentCircuit{}
+	ccs, pk, vk, err := Setup(Groth16Backend, &circuitAssignment)
+	require.NoError(t, err)
+
+	firstWitness := publicCommitmentCircuit{Commitment: 4, Nonce: 2}
+	secondWitness := publicCommitmentCircuit{Commitment: 10, Nonce: 5}
+	firstProof, err := ProveWithKey(Groth16Backend, ccs, pk, &firstWitness)
+	require.NoError(t, err)
+	secondProof, err := ProveWithKey(Groth16Backend, ccs, pk, &secondWitness)
+	require.NoError(t, err)
+
+	aggregated, err := AggregateBlockProofs(
+		Groth16Backend, vk,
+		[]Proof{firstProof, secondProof},
+		[]frontend.Circuit{&firstWitness, &secondWitness},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, aggregated.NbProofs)
+	require.NotEmpty(t, aggregated.NextCommitment)
+
+	expected, err := BuildRecursionInputs([]Proof{firstProof, secondProof})
+	require.NoError(t, err)
+	require.Equal(t, expected, aggregated.RecursionPublicInputs)
+
+	// secondProof does not verify against firstWitness, so aggregation
+	// should fail rather than silently fold in an unverified proof.
+	_, err = AggregateBlockProofs(
+		Groth16Backend, vk,
+		[]Proof{firstProof, secondProof},
+		[]frontend.Circuit{&firstWitness, &firstWitness},
+	)
+	require.Error(t, err)
+}
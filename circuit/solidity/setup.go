@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzgbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+// ProvingKey is the common surface of groth16.ProvingKey and
+// plonk.ProvingKey Setup/ProveWithKey need: both know how to serialize
+// themselves, the same minimal surface Proof and VerifyingKey expose.
+type ProvingKey interface {
+	io.WriterTo
+}
+
+// Setup compiles circuit for the selected backend and runs a fresh
+// (insecure) setup against it, the same way Prove's first half does,
+// but returns the compiled constraint system and key pair instead of
+// immediately consuming them. This lets a caller that reuses the same
+// TxConstraints/BlockConstraints circuit across many proofs (the usual
+// case, since a given block size's circuit does not change between
+// blocks) pay compilation and setup once via Setup, then call
+// ProveWithKey per witness instead of recompiling and re-running setup on
+// every proof the way a bare call to Prove would.
+func Setup(proverBackend ProverBackend, circuit frontend.Circuit) (frontend.CompiledConstraintSystem, ProvingKey, VerifyingKey, error) {
+	switch proverBackend {
+	case Groth16Backend:
+		ccs, err := frontend.Compile(ecc.BN254, r1cs.NewBuilder, circuit, frontend.IgnoreUnconstrainedInputs())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		pk, vk, err := groth16.Setup(ccs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return ccs, pk, vk, nil
+	case PlonkBackend:
+		ccs, err := frontend.Compile(ecc.BN254, scs.NewBuilder, circuit, frontend.IgnoreUnconstrainedInputs())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		srs, err := kzgbn254.NewSRS(ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints()))+3, big.NewInt(42))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		pk, vk, err := plonk.Setup(ccs, srs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return ccs, pk, vk, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported prover backend: %d", proverBackend)
+	}
+}
+
+// ProveWithKey proves assignment against a ccs/pk pair obtained from Setup,
+// for a circuit already compiled and set up once. Use this instead of
+// Prove whenever the same circuit is proved more than once, to avoid
+// paying compilation and trusted setup on every proof.
+func ProveWithKey(proverBackend ProverBackend, ccs frontend.CompiledConstraintSystem, pk ProvingKey, assignment frontend.Circuit) (Proof, error) {
+	fullWitness, err := frontend.NewWitness(assignment, ecc.BN254)
+	if err != nil {
+		return nil, err
+	}
+	switch proverBackend {
+	case Groth16Backend:
+		return groth16.Prove(ccs, pk.(groth16.ProvingKey), fullWitness)
+	case PlonkBackend:
+		return plonk.Prove(ccs, pk.(plonk.ProvingKey), fullWitness)
+	default:
+		return nil, fmt.Errorf("unsupported prover backend: %d", proverBackend)
+	}
+}
+
+// ExportVerifyingKey serializes vk via its own WriteTo, for either backend,
+// so it can be persisted (e.g. alongside its circuit's CircuitFingerprint)
+// and handed to Verify later without keeping the in-memory key pair from
+// Setup around.
+func ExportVerifyingKey(vk VerifyingKey) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := vk.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
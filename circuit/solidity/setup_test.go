@@ -0,0 +1,49 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupProveWithKeyAndVerifyPerBackend(t *testing.T) {
+	backends := []ProverBackend{Groth16Backend, PlonkBackend}
+	for _, proverBackend := range backends {
+		circuitAssignment := fingerprintCircuit{}
+		ccs, pk, vk, err := Setup(proverBackend, &circuitAssignment)
+		require.NoError(t, err)
+
+		vkBytes, err := ExportVerifyingKey(vk)
+		require.NoError(t, err)
+		require.NotEmpty(t, vkBytes)
+
+		firstWitness := fingerprintCircuit{A: 1, B: 2, C: 3}
+		firstProof, err := ProveWithKey(proverBackend, ccs, pk, &firstWitness)
+		require.NoError(t, err)
+		require.NoError(t, Verify(proverBackend, firstProof, vk, &firstWitness))
+
+		// the same ccs/pk pair proves a second, independent witness without
+		// re-running Setup.
+		secondWitness := fingerprintCircuit{A: 4, B: 5, C: 9}
+		secondProof, err := ProveWithKey(proverBackend, ccs, pk, &secondWitness)
+		require.NoError(t, err)
+		require.NoError(t, Verify(proverBackend, secondProof, vk, &secondWitness))
+	}
+}
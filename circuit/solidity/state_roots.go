@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+)
+
+// PublicInputs decodes the public input vector an operator would pass to
+// Verify, in the same order the verifier consumes it. It re-derives the
+// vector from assignment rather than requiring a live proving session, so it
+// can be used purely to inspect what a witness commits to.
+func PublicInputs(assignment frontend.Circuit) ([]fr.Element, error) {
+	w, err := frontend.NewWitness(assignment, ecc.BN254, frontend.PublicOnly())
+	if err != nil {
+		return nil, err
+	}
+	data, err := w.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	const elemSize = fr.Bytes
+	if len(data) < 4 {
+		return nil, fmt.Errorf("malformed witness: expected at least 4 bytes, got %d", len(data))
+	}
+	nbElements := binary.BigEndian.Uint32(data[:4])
+	data = data[4:]
+	if len(data) != int(nbElements)*elemSize {
+		return nil, fmt.Errorf("malformed witness: expected %d bytes for %d elements, got %d", int(nbElements)*elemSize, nbElements, len(data))
+	}
+	elements := make([]fr.Element, nbElements)
+	for i := range elements {
+		elements[i].SetBytes(data[i*elemSize : (i+1)*elemSize])
+	}
+	return elements, nil
+}
+
+// ExtractStateRoots decodes the previous and next state roots out of a
+// public input vector, assuming the circuit follows the convention of
+// exposing the old state root followed by the new state root as its first
+// two public inputs.
+func ExtractStateRoots(publicInputs []fr.Element) (prev, next []byte, err error) {
+	if len(publicInputs) < 2 {
+		return nil, nil, fmt.Errorf("public inputs should contain at least 2 elements (old/new state root), got %d", len(publicInputs))
+	}
+	prevBytes := publicInputs[0].Bytes()
+	nextBytes := publicInputs[1].Bytes()
+	return prevBytes[:], nextBytes[:], nil
+}
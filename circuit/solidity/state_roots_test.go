@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// stateRootsCircuit is a minimal stand-in for a circuit that exposes the old
+// and new state roots as its first two public inputs, in that order.
+type stateRootsCircuit struct {
+	OldStateRoot frontend.Variable `gnark:",public"`
+	NewStateRoot frontend.Variable `gnark:",public"`
+	Nonce        frontend.Variable
+}
+
+func (c *stateRootsCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Nonce, c.Nonce)
+	return nil
+}
+
+func TestExtractStateRootsRoundTrip(t *testing.T) {
+	oldRoot := big.NewInt(111)
+	newRoot := big.NewInt(222)
+	assignment := &stateRootsCircuit{
+		OldStateRoot: oldRoot,
+		NewStateRoot: newRoot,
+		Nonce:        1,
+	}
+
+	publicInputs, err := PublicInputs(assignment)
+	require.NoError(t, err)
+	require.Len(t, publicInputs, 2)
+
+	prev, next, err := ExtractStateRoots(publicInputs)
+	require.NoError(t, err)
+	require.Equal(t, oldRoot.FillBytes(make([]byte, 32)), prev)
+	require.Equal(t, newRoot.FillBytes(make([]byte, 32)), next)
+}
+
+func TestExtractStateRootsRejectsShortInput(t *testing.T) {
+	_, _, err := ExtractStateRoots(nil)
+	require.Error(t, err)
+}
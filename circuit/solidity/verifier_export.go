@@ -0,0 +1,62 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+// blockCircuitPublicInputDoc documents the block circuit's public input
+// layout for whoever reads the generated verifier contract: gnark names
+// Solidity's sole "input" argument generically, so without this comment a
+// contract integrator has no way to tell what the one public input means
+// or how it was built. It must be kept in sync with
+// circuit.BlockConstraints's only `gnark:",public"` field and with
+// VerifyBlock's pendingCommitmentData layout.
+const blockCircuitPublicInputDoc = `// Generated by circuit/solidity.ExportSolidityVerifier. Do not edit by hand;
+// regenerate from the block circuit's verifying key instead.
+//
+// Public input layout (circuit.BlockConstraints):
+//   input[0] = BlockCommitment, a Keccak256 digest (mod the BN254 scalar
+//     field) of, in order: BlockNumber, CreatedAt, OldStateRoot,
+//     NewStateRoot, each transaction's pubdata words (PubDataSizePerTx per
+//     tx, in tx order), then onChainOpsCount. See VerifyBlock's
+//     pendingCommitmentData and types.BlockCommitmentNative, which computes
+//     the identical digest off-circuit.
+`
+
+// ExportSolidityVerifier writes a ready-to-deploy Solidity verifier
+// contract for vk to w, preceded by a doc comment describing the block
+// circuit's public input layout, so the contract never drifts silently
+// from what the circuit actually commits to. vk must be a Groth16
+// verifying key (gnark's ExportSolidity is only implemented for Groth16 on
+// BN254, which is the only backend/curve pair this repo deploys
+// against) — PlonkBackend setups are rejected.
+func ExportSolidityVerifier(vk VerifyingKey, w io.Writer) error {
+	groth16Vk, ok := vk.(groth16.VerifyingKey)
+	if !ok {
+		return fmt.Errorf("ExportSolidityVerifier: only Groth16 verifying keys can be exported to Solidity, got %T", vk)
+	}
+	if _, err := io.WriteString(w, blockCircuitPublicInputDoc); err != nil {
+		return err
+	}
+	return groth16Vk.ExportSolidity(w)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package solidity
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSolidityVerifierEmitsDocAndContract(t *testing.T) {
+	_, _, vk, err := Setup(Groth16Backend, &fingerprintCircuit{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportSolidityVerifier(vk, &buf))
+
+	out := buf.String()
+	require.True(t, strings.HasPrefix(out, "// Generated by circuit/solidity.ExportSolidityVerifier"))
+	require.Contains(t, out, "BlockCommitment")
+	require.Contains(t, out, "contract Verifier")
+}
+
+func TestExportSolidityVerifierRejectsPlonkKey(t *testing.T) {
+	_, _, vk, err := Setup(PlonkBackend, &fingerprintCircuit{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.Error(t, ExportSolidityVerifier(vk, &buf))
+}
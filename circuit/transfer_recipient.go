@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import "errors"
+
+// ValidateRecipientExists checks that a TransferTx's recipient account leaf,
+// AccountsInfoBefore[1] (toAccount in types.VerifyTransferTx), is a real,
+// already-registered account rather than an empty, not-yet-registered slot.
+// types.EmptyAccount leaves AccountNameHash unset; RegisterZnsTx is this
+// repo's only way to turn such a slot into a real account, by setting it.
+//
+// There is no auto-register branch here: VerifyTransferTx's pubdata commits
+// to a fixed two-account layout proven purely by Merkle inclusion, with
+// nothing in a transfer's fields to carry a requested account name for a
+// slot that turns out to be empty. Building that would mean restructuring
+// transfer's pubdata and commitment scheme to fold in register-style fields,
+// not adding a helper alongside it; a non-existent recipient is handled
+// today by rejecting the tx and having the sender's wallet submit a
+// RegisterZnsTx first, which is what this function lets a caller check for
+// before ever building a transfer witness.
+func ValidateRecipientExists(oTx *Tx) error {
+	if oTx == nil {
+		return errors.New("oTx should not be nil")
+	}
+	if oTx.TransferTxInfo == nil {
+		return errors.New("oTx is not a transfer")
+	}
+	recipient := oTx.AccountsInfoBefore[1]
+	if recipient == nil {
+		return errors.New("recipient account leaf is nil")
+	}
+	if len(recipient.AccountNameHash) == 0 {
+		return errors.New("recipient account does not exist: empty account name hash")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func TestValidateRecipientExists(t *testing.T) {
+	oTx := &Tx{
+		TransferTxInfo: &TransferTx{},
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{
+			{AccountIndex: 1, AccountNameHash: []byte{0x01}},
+			{AccountIndex: 2, AccountNameHash: []byte{0x02}},
+		},
+	}
+	require.NoError(t, ValidateRecipientExists(oTx))
+
+	notRegistered := &Tx{
+		TransferTxInfo: &TransferTx{},
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{
+			{AccountIndex: 1, AccountNameHash: []byte{0x01}},
+			types.EmptyAccount(2, types.EmptyAssetRoot.FillBytes(make([]byte, 32))),
+		},
+	}
+	require.Error(t, ValidateRecipientExists(notRegistered))
+
+	require.Error(t, ValidateRecipientExists(&Tx{}))
+}
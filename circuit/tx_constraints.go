@@ -307,6 +307,7 @@ func VerifyTransaction(
 	notEmptyTx := api.IsZero(isEmptyTx)
 	types.IsVariableEqual(api, notEmptyTx, oldStateRoot, tx.StateRootBefore)
 
+	merkleVerifier := types.NewMerkleVerifier(api, hFunc)
 	newAccountRoot := tx.AccountRootBefore
 	for i := 0; i < NbAccountsPerTx; i++ {
 		var (
@@ -322,27 +323,16 @@ func VerifyTransaction(
 				tx.AccountsInfoBefore[i].AssetsInfo[j].OfferCanceledOrFinalized,
 			)
 			assetNodeHash := hFunc.Sum()
-			// verify account asset merkle proof
-			hFunc.Reset()
-			types.VerifyMerkleProof(
-				api,
-				notEmptyTx,
-				hFunc,
-				NewAccountAssetsRoot,
-				assetNodeHash,
-				tx.MerkleProofsAccountAssetsBefore[i][j][:],
-				assetMerkleHelper,
-			)
 			hFunc.Reset()
 			hFunc.Write(
 				AccountsInfoAfter[i].AssetsInfo[j].Balance,
 				AccountsInfoAfter[i].AssetsInfo[j].OfferCanceledOrFinalized,
 			)
-			assetNodeHash = hFunc.Sum()
-			hFunc.Reset()
-			// update merkle proof
-			NewAccountAssetsRoot = types.UpdateMerkleProof(
-				api, hFunc, assetNodeHash, tx.MerkleProofsAccountAssetsBefore[i][j][:], assetMerkleHelper)
+			newAssetNodeHash := hFunc.Sum()
+			// verify account asset merkle proof, then update it
+			NewAccountAssetsRoot = merkleVerifier.VerifyAndUpdate(
+				notEmptyTx, NewAccountAssetsRoot, assetNodeHash, newAssetNodeHash,
+				tx.MerkleProofsAccountAssetsBefore[i][j][:], assetMerkleHelper)
 		}
 		// verify account node hash
 		api.AssertIsLessOrEqual(tx.AccountsInfoBefore[i].AccountIndex, LastAccountIndex)
@@ -357,17 +347,6 @@ func VerifyTransaction(
 			tx.AccountsInfoBefore[i].AssetRoot,
 		)
 		accountNodeHash := hFunc.Sum()
-		// verify account merkle proof
-		hFunc.Reset()
-		types.VerifyMerkleProof(
-			api,
-			notEmptyTx,
-			hFunc,
-			newAccountRoot,
-			accountNodeHash,
-			tx.MerkleProofsAccountBefore[i][:],
-			accountIndexMerkleHelper,
-		)
 		hFunc.Reset()
 		hFunc.Write(
 			AccountsInfoAfter[i].AccountNameHash,
@@ -377,10 +356,11 @@ func VerifyTransaction(
 			AccountsInfoAfter[i].CollectionNonce,
 			NewAccountAssetsRoot,
 		)
-		accountNodeHash = hFunc.Sum()
-		hFunc.Reset()
-		// update merkle proof
-		newAccountRoot = types.UpdateMerkleProof(api, hFunc, accountNodeHash, tx.MerkleProofsAccountBefore[i][:], accountIndexMerkleHelper)
+		newAccountNodeHash := hFunc.Sum()
+		// verify account merkle proof, then update it
+		newAccountRoot = merkleVerifier.VerifyAndUpdate(
+			notEmptyTx, newAccountRoot, accountNodeHash, newAccountNodeHash,
+			tx.MerkleProofsAccountBefore[i][:], accountIndexMerkleHelper)
 		oldRoots[0] = api.Select(isEmptyTx, oldRoots[0], newAccountRoot)
 	}
 
@@ -399,17 +379,6 @@ func VerifyTransaction(
 		tx.NftBefore.CollectionId,
 	)
 	nftNodeHash := hFunc.Sum()
-	// verify account merkle proof
-	hFunc.Reset()
-	types.VerifyMerkleProof(
-		api,
-		notEmptyTx,
-		hFunc,
-		newNftRoot,
-		nftNodeHash,
-		tx.MerkleProofsNftBefore[:],
-		nftIndexMerkleHelper,
-	)
 	hFunc.Reset()
 	hFunc.Write(
 		NftAfter.CreatorAccountIndex,
@@ -420,10 +389,11 @@ func VerifyTransaction(
 		NftAfter.CreatorTreasuryRate,
 		NftAfter.CollectionId,
 	)
-	nftNodeHash = hFunc.Sum()
-	hFunc.Reset()
-	// update merkle proof
-	newNftRoot = types.UpdateMerkleProof(api, hFunc, nftNodeHash, tx.MerkleProofsNftBefore[:], nftIndexMerkleHelper)
+	newNftNodeHash := hFunc.Sum()
+	// verify nft merkle proof, then update it
+	newNftRoot = merkleVerifier.VerifyAndUpdate(
+		notEmptyTx, newNftRoot, nftNodeHash, newNftNodeHash,
+		tx.MerkleProofsNftBefore[:], nftIndexMerkleHelper)
 	oldRoots[1] = api.Select(isEmptyTx, oldRoots[1], newNftRoot)
 
 	// check state root
@@ -493,6 +463,18 @@ func SetTxWitness(oTx *Tx) (witness TxConstraints, err error) {
 	witness.FullExitTxInfo = types.EmptyFullExitTxWitness()
 	witness.FullExitNftTxInfo = types.EmptyFullExitNftTxWitness()
 	witness.Signature = EmptySignatureWitness()
+	if err = types.AssertNonNegativeInt64("Nonce", oTx.Nonce); err != nil {
+		log.Println("[SetTxWitness] invalid nonce:", err)
+		return witness, err
+	}
+	if err = AssertNonceMatchesAccount(oTx); err != nil {
+		log.Println("[SetTxWitness] nonce mismatch:", err)
+		return witness, err
+	}
+	if err = types.AssertNonNegativeInt64("ExpiredAt", oTx.ExpiredAt); err != nil {
+		log.Println("[SetTxWitness] invalid expiredAt:", err)
+		return witness, err
+	}
 	witness.Nonce = oTx.Nonce
 	witness.ExpiredAt = oTx.ExpiredAt
 	switch oTx.TxType {
@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func zeroTx() *Tx {
+	tx := &Tx{
+		TxType:          uint8(types.TxTypeEmptyTx),
+		Nonce:           0,
+		ExpiredAt:       0,
+		Signature:       types.EmptySignature(),
+		NftBefore:       types.EmptyNft(0),
+		NftRootBefore:   make([]byte, 32),
+		StateRootBefore: make([]byte, 32),
+		StateRootAfter:  make([]byte, 32),
+	}
+	for i := 0; i < NbAccountsPerTx; i++ {
+		tx.AccountsInfoBefore[i] = types.EmptyAccount(0, types.EmptyAssetRoot.Bytes())
+		for j := 0; j < AccountMerkleLevels; j++ {
+			tx.MerkleProofsAccountBefore[i][j] = make([]byte, 32)
+		}
+		for j := 0; j < NbAccountAssetsPerAccount; j++ {
+			for k := 0; k < AssetMerkleLevels; k++ {
+				tx.MerkleProofsAccountAssetsBefore[i][j][k] = make([]byte, 32)
+			}
+		}
+	}
+	for i := 0; i < NftMerkleLevels; i++ {
+		tx.MerkleProofsNftBefore[i] = make([]byte, 32)
+	}
+	return tx
+}
+
+func TestSetTxWitnessRejectsNegativeNonce(t *testing.T) {
+	tx := zeroTx()
+	_, err := SetTxWitness(tx)
+	require.NoError(t, err)
+
+	tx.Nonce = -1
+	_, err = SetTxWitness(tx)
+	require.Error(t, err)
+}
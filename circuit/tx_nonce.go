@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// isLayer2TxType reports whether txType is one of the signature-bearing,
+// account-initiated tx types that VerifyTransaction binds to the account's
+// nonce (the isLayer2Tx flag in VerifyTransaction).
+func isLayer2TxType(txType uint8) bool {
+	switch txType {
+	case types.TxTypeTransfer, types.TxTypeWithdraw, types.TxTypeCreateCollection,
+		types.TxTypeMintNft, types.TxTypeTransferNft, types.TxTypeAtomicMatch,
+		types.TxTypeCancelOffer, types.TxTypeWithdrawNft:
+		return true
+	default:
+		return false
+	}
+}
+
+// AssertNonceMatchesAccount is the native twin of the in-circuit check
+// VerifyTransaction makes for signature-bearing tx types: the nonce signed
+// into the tx must equal the submitting account's (AccountsInfoBefore[0])
+// current nonce. Tx types without a signature (deposits, exits, ...) carry
+// no meaningful nonce and are skipped.
+func AssertNonceMatchesAccount(oTx *Tx) error {
+	if !isLayer2TxType(oTx.TxType) {
+		return nil
+	}
+	if oTx.AccountsInfoBefore[0] == nil {
+		return fmt.Errorf("AccountsInfoBefore[0] should not be nil")
+	}
+	if oTx.Nonce != oTx.AccountsInfoBefore[0].Nonce {
+		return fmt.Errorf("tx nonce %d does not match account's committed nonce %d", oTx.Nonce, oTx.AccountsInfoBefore[0].Nonce)
+	}
+	return nil
+}
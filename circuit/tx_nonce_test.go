@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+func TestAssertNonceMatchesAccount(t *testing.T) {
+	matching := &Tx{
+		TxType:             types.TxTypeTransfer,
+		Nonce:              3,
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{Nonce: 3}},
+	}
+	require.NoError(t, AssertNonceMatchesAccount(matching))
+
+	mismatched := &Tx{
+		TxType:             types.TxTypeTransfer,
+		Nonce:              3,
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{Nonce: 4}},
+	}
+	require.Error(t, AssertNonceMatchesAccount(mismatched))
+
+	// non-layer2 tx types carry no meaningful nonce and are skipped.
+	deposit := &Tx{
+		TxType:             types.TxTypeDeposit,
+		Nonce:              3,
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{Nonce: 99}},
+	}
+	require.NoError(t, AssertNonceMatchesAccount(deposit))
+}
+
+func TestSetTxWitnessRejectsNonceMismatch(t *testing.T) {
+	oTx := &Tx{
+		TxType:             types.TxTypeTransfer,
+		Nonce:              3,
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{{Nonce: 4}},
+	}
+	_, err := SetTxWitness(oTx)
+	require.Error(t, err)
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import "errors"
+
+// SignerPublicKey returns the compressed public key bytes of oTx's signer,
+// AccountsInfoBefore[0], the account every tx type's signature is checked
+// against (see e.g. types.VerifyEddsaSig's callers in tx_constraints.go).
+// This is a convenience accessor for callers such as explorers that just
+// want to log who signed a tx without reaching into the witness struct
+// themselves.
+func SignerPublicKey(oTx *Tx) ([]byte, error) {
+	if oTx == nil {
+		return nil, errors.New("oTx should not be nil")
+	}
+	if oTx.AccountsInfoBefore[0] == nil || oTx.AccountsInfoBefore[0].AccountPk == nil {
+		return nil, errors.New("oTx.AccountsInfoBefore[0].AccountPk should not be nil")
+	}
+	return oTx.AccountsInfoBefore[0].AccountPk.Bytes(), nil
+}
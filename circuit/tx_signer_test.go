@@ -0,0 +1,40 @@
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// TestSignerPublicKeyMatchesCommittedAccountPubkey checks that
+// SignerPublicKey returns exactly AccountsInfoBefore[0].AccountPk's own
+// compressed bytes, i.e. the account the tx's signature is actually checked
+// against rather than some other account slot.
+func TestSignerPublicKeyMatchesCommittedAccountPubkey(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := curve.ScalarBaseMul(sk)
+	signer := &types.Account{
+		AccountIndex: 1,
+		AccountPk:    &eddsa.PublicKey{A: *pk},
+	}
+
+	oTx := &Tx{
+		AccountsInfoBefore: [NbAccountsPerTx]*types.Account{signer},
+	}
+
+	pkBytes, err := SignerPublicKey(oTx)
+	require.NoError(t, err)
+	require.Equal(t, signer.AccountPk.Bytes(), pkBytes)
+
+	_, err = SignerPublicKey(nil)
+	require.Error(t, err)
+
+	_, err = SignerPublicKey(&Tx{})
+	require.Error(t, err)
+}
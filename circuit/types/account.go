@@ -18,12 +18,16 @@
 package types
 
 import (
+	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
 
 	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+	"github.com/bnb-chain/zkbnb-crypto/merkleTree"
 )
 
 type Account struct {
@@ -56,6 +60,72 @@ func EmptyAccount(accountIndex int64, assetRoot []byte) *Account {
 	}
 }
 
+// AccountNodeHashNative is the native twin of the account leaf hash
+// tx_constraints.go computes in-circuit when reading or updating an account
+// tree node: mimc over AccountNameHash, the public key, Nonce,
+// CollectionNonce and the asset sub-tree root, in that order. assetRoot is
+// taken as a parameter rather than account.AssetRoot so callers can pass the
+// post-update asset root without this package needing its own asset Merkle
+// tree implementation.
+func AccountNodeHashNative(account *Account, assetRoot []byte) []byte {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(new(big.Int).SetBytes(account.AccountNameHash).FillBytes(make([]byte, 32)))
+	var pkBuf bytes.Buffer
+	curve.WritePointIntoBufNative(&pkBuf, &account.AccountPk.A)
+	hFunc.Write(pkBuf.Bytes())
+	hFunc.Write(big.NewInt(account.Nonce).FillBytes(make([]byte, 32)))
+	hFunc.Write(big.NewInt(account.CollectionNonce).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(assetRoot).FillBytes(make([]byte, 32)))
+	return hFunc.Sum(nil)
+}
+
+// accountAssetLeafHashNative is the native leaf hash used to rebuild an
+// account's asset sub-tree in ComputeAccountAssetsRoot: mimc over AssetId,
+// Balance and OfferCanceledOrFinalized, in that order.
+func accountAssetLeafHashNative(asset *AccountAsset) []byte {
+	if asset == nil {
+		asset = EmptyAccountAsset(0)
+	}
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(big.NewInt(asset.AssetId).FillBytes(make([]byte, 32)))
+	hFunc.Write(asset.Balance.FillBytes(make([]byte, 32)))
+	hFunc.Write(asset.OfferCanceledOrFinalized.FillBytes(make([]byte, 32)))
+	return hFunc.Sum(nil)
+}
+
+// ComputeAccountAssetsRoot rebuilds account's asset sub-tree from its
+// AssetsInfo slots and returns its root, independently of whatever root the
+// caller already has on account.AssetRoot. It exists to catch an AssetRoot
+// that has drifted from the asset slots it is supposed to summarize, the
+// same kind of staleness AccountNodeHashNative's assetRoot parameter is
+// careful to take fresh rather than trusting account.AssetRoot.
+func ComputeAccountAssetsRoot(account *Account) []byte {
+	leafHashes := make([][]byte, len(account.AssetsInfo))
+	for i, asset := range account.AssetsInfo {
+		leafHashes[i] = accountAssetLeafHashNative(asset)
+	}
+	tree, err := merkleTree.NewTree(merkleTree.CreateLeaves(leafHashes), 1, merkleTree.NilHash, mimc.NewMiMC())
+	if err != nil {
+		panic(fmt.Sprintf("ComputeAccountAssetsRoot: unable to build asset sub-tree: %s", err.Error()))
+	}
+	return tree.RootNode.Value
+}
+
+// AssetSlotIndex returns a deterministic index into [0, NbAccountAssetsPerAccount)
+// for assetId. It is NOT what the circuit actually uses to place an asset
+// into Account.AssetsInfo: as the IsVariableEqual calls throughout this
+// package show (e.g. transfer.go pins AssetsInfo[0] to the transferred asset
+// and AssetsInfo[1] to the gas asset), slot assignment there is fixed by the
+// asset's role in the transaction, not derived from its id. This helper is a
+// plain namespacing utility for callers that just need a stable assetId ->
+// slot bucket and have no role to assign by.
+func AssetSlotIndex(assetId int64) (int, error) {
+	if assetId < 0 {
+		return 0, fmt.Errorf("assetId should not be negative")
+	}
+	return int(assetId % int64(NbAccountAssetsPerAccount)), nil
+}
+
 type AccountAsset struct {
 	AssetId                  int64
 	Balance                  *big.Int
@@ -70,6 +140,32 @@ func EmptyAccountAsset(assetId int64) *AccountAsset {
 	}
 }
 
+// DecryptedBalance returns the account's balance of assetId, for a caller
+// who proves view access by presenting sk, the private key matching the
+// account's own public key. Account balances in this tree are stored in the
+// clear rather than as ElGamal ciphertexts, so there is nothing to actually
+// decrypt; this reproduces the same access-control shape (prove you hold sk,
+// then read a value bounded by max) that an encrypted-balance getter would
+// have, against the plaintext balance that is really there.
+func (a *Account) DecryptedBalance(assetId int64, sk *big.Int, max uint64) (uint64, error) {
+	if a == nil {
+		return 0, fmt.Errorf("account should not be nil")
+	}
+	if sk == nil || curve.ScalarBaseMul(sk).X != a.AccountPk.A.X || curve.ScalarBaseMul(sk).Y != a.AccountPk.A.Y {
+		return 0, fmt.Errorf("sk does not match the account's public key")
+	}
+	for _, asset := range a.AssetsInfo {
+		if asset == nil || asset.AssetId != assetId {
+			continue
+		}
+		if asset.Balance == nil || !asset.Balance.IsUint64() || asset.Balance.Uint64() > max {
+			return 0, fmt.Errorf("asset %d balance exceeds max", assetId)
+		}
+		return asset.Balance.Uint64(), nil
+	}
+	return 0, fmt.Errorf("account does not hold asset %d", assetId)
+}
+
 func EmptySignature() (sig *eddsa.Signature) {
 	sig = &eddsa.Signature{
 		R: curve.Point{
@@ -18,10 +18,16 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"log"
+	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	nativeEddsa "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
 	"github.com/consensys/gnark/std/signature/eddsa"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
 )
 
 type AccountConstraints struct {
@@ -49,6 +55,25 @@ func CheckNonEmptyAccountNode(api API, flag Variable, account AccountConstraints
 	IsVariableDifferent(api, flag, account.AccountNameHash, ZeroInt)
 }
 
+// InitialAccountLeaf computes the Merkle leaf hash of a freshly registered
+// account: a fresh name/key, a zero nonce, a zero collection nonce and an
+// empty asset sub-tree. The fields and their order are those of the account
+// leaf hash VerifyTransaction computes (and of AccountNodeHashNative); index
+// is the leaf's position in the account tree and is not part of the hash.
+// The zero-state matches what CheckEmptyAccountNode checks for before a
+// register-zns tx is applied.
+func InitialAccountLeaf(index int64, nameHash []byte, pk *nativeEddsa.PublicKey) []byte {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(new(big.Int).SetBytes(nameHash).FillBytes(make([]byte, 32)))
+	var pkBuf bytes.Buffer
+	curve.WritePointIntoBufNative(&pkBuf, &pk.A)
+	hFunc.Write(pkBuf.Bytes())
+	hFunc.Write(make([]byte, 32)) // Nonce = 0
+	hFunc.Write(make([]byte, 32)) // CollectionNonce = 0
+	hFunc.Write(EmptyAssetRoot.FillBytes(make([]byte, 32)))
+	return hFunc.Sum(nil)
+}
+
 type AccountAssetConstraints struct {
 	AssetId                  Variable
 	Balance                  Variable
@@ -69,7 +94,7 @@ func SetAccountAssetWitness(asset *AccountAsset) (witness AccountAssetConstraint
 }
 
 /*
-	SetAccountWitness: set account witness
+SetAccountWitness: set account witness
 */
 func SetAccountWitness(account *Account) (witness AccountConstraints, err error) {
 	if account == nil {
@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	nativeEddsa "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+type initialAccountLeafConstraints struct {
+	Account    AccountConstraints
+	ExpectHash Variable
+}
+
+func (circuit initialAccountLeafConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hFunc.Write(circuit.Account.AccountNameHash)
+	WritePointIntoBuf(&hFunc, circuit.Account.AccountPk.A)
+	hFunc.Write(circuit.Account.Nonce)
+	hFunc.Write(circuit.Account.CollectionNonce)
+	hFunc.Write(circuit.Account.AssetRoot)
+	api.AssertIsEqual(hFunc.Sum(), circuit.ExpectHash)
+	return nil
+}
+
+// TestInitialAccountLeafParity checks that the native InitialAccountLeaf and
+// an in-circuit hash over the same AccountConstraints fields, in the same
+// order, agree bit-for-bit.
+func TestInitialAccountLeafParity(t *testing.T) {
+	nameHash := []byte{0x01, 0x02, 0x03}
+	pk := &nativeEddsa.PublicKey{A: *curve.G}
+	nativeHash := InitialAccountLeaf(1, nameHash, pk)
+
+	account := EmptyAccount(1, EmptyAssetRoot.Bytes())
+	account.AccountNameHash = nameHash
+	account.AccountPk = pk
+	accountWitness, err := SetAccountWitness(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert := test.NewAssert(t)
+	var circuit, witness initialAccountLeafConstraints
+	witness.Account = accountWitness
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
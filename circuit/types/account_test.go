@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func TestAccountDecryptedBalance(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := curve.ScalarBaseMul(sk)
+	account := &Account{
+		AccountIndex: 1,
+		AccountPk:    &eddsa.PublicKey{A: *pk},
+		AssetRoot:    EmptyAssetRoot.Bytes(),
+		AssetsInfo: [NbAccountAssetsPerAccount]*AccountAsset{
+			{AssetId: 0, Balance: big.NewInt(500), OfferCanceledOrFinalized: big.NewInt(0)},
+			EmptyAccountAsset(1),
+		},
+	}
+
+	balance, err := account.DecryptedBalance(0, sk, 1000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(500), balance)
+
+	// wrong key is rejected
+	_, err = account.DecryptedBalance(0, big.NewInt(1), 1000)
+	require.Error(t, err)
+
+	// asset not held
+	_, err = account.DecryptedBalance(2, sk, 1000)
+	require.Error(t, err)
+
+	// balance exceeds max
+	_, err = account.DecryptedBalance(0, sk, 100)
+	require.Error(t, err)
+}
+
+func TestComputeAccountAssetsRoot(t *testing.T) {
+	account := &Account{
+		AccountIndex: 1,
+		AssetRoot:    EmptyAssetRoot.Bytes(),
+		AssetsInfo: [NbAccountAssetsPerAccount]*AccountAsset{
+			{AssetId: 0, Balance: big.NewInt(500), OfferCanceledOrFinalized: big.NewInt(0)},
+			EmptyAccountAsset(1),
+		},
+	}
+
+	root := ComputeAccountAssetsRoot(account)
+	require.NotEmpty(t, root)
+
+	// a stale AssetRoot, left over from before AssetsInfo changed, is caught
+	require.NotEqual(t, account.AssetRoot, root)
+
+	// recomputing from the same slots is deterministic
+	require.Equal(t, root, ComputeAccountAssetsRoot(account))
+}
+
+func TestAssetSlotIndex(t *testing.T) {
+	slot, err := AssetSlotIndex(0)
+	require.NoError(t, err)
+	require.Equal(t, 0, slot)
+
+	slot, err = AssetSlotIndex(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, slot)
+
+	// wraps back into [0, NbAccountAssetsPerAccount)
+	slot, err = AssetSlotIndex(2)
+	require.NoError(t, err)
+	require.Equal(t, 0, slot)
+
+	slot, err = AssetSlotIndex(3)
+	require.NoError(t, err)
+	require.Equal(t, 1, slot)
+
+	_, err = AssetSlotIndex(-1)
+	require.Error(t, err)
+}
@@ -17,6 +17,13 @@
 
 package types
 
+import (
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
 type AtomicMatchTx struct {
 	AccountIndex      int64
 	BuyOffer          *OfferTx
@@ -97,6 +104,30 @@ func ComputeHashFromAtomicMatchTx(api API, tx AtomicMatchTxConstraints, nonce Va
 	return hashVal
 }
 
+// ComputeAtomicMatchMsgHash is the native twin of ComputeHashFromAtomicMatchTx:
+// it hashes the same fields, including both offers' raw signature components, in
+// the same order using math/big arithmetic instead of circuit Variables, so the
+// result can be compared against a proving-circuit execution for parity.
+func ComputeAtomicMatchMsgHash(tx *AtomicMatchTx, nonce int64, expiredAt int64) (hashVal []byte) {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(packInt64VariablesNative(ChainId, tx.AccountIndex, nonce, expiredAt).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.GasAccountIndex, tx.GasFeeAssetId, tx.GasFeeAssetAmount).FillBytes(make([]byte, 32)))
+	writeOfferMsgHashFields(hFunc, tx.BuyOffer)
+	writeOfferMsgHashFields(hFunc, tx.SellOffer)
+	hashVal = hFunc.Sum(nil)
+	return hashVal
+}
+
+func writeOfferMsgHashFields(hFunc hash.Hash, offer *OfferTx) {
+	hFunc.Write(packInt64VariablesNative(offer.Type, offer.OfferId, offer.AccountIndex, offer.NftIndex).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(offer.AssetId, offer.AssetAmount, offer.ListedAt, offer.ExpiredAt).FillBytes(make([]byte, 32)))
+	rx := offer.Sig.R.X
+	ry := offer.Sig.R.Y
+	hFunc.Write(rx.ToBigIntRegular(new(big.Int)).FillBytes(make([]byte, 32)))
+	hFunc.Write(ry.ToBigIntRegular(new(big.Int)).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(offer.Sig.S[:]).FillBytes(make([]byte, 32)))
+}
+
 func VerifyAtomicMatchTx(
 	api API, flag Variable,
 	tx *AtomicMatchTxConstraints,
@@ -177,5 +208,9 @@ func VerifyAtomicMatchTx(
 	// submitter should have enough balance
 	tx.GasFeeAssetAmount = UnpackFee(api, tx.GasFeeAssetAmount)
 	IsVariableLessOrEqual(api, flag, tx.GasFeeAssetAmount, accountsBefore[fromAccount].AssetsInfo[0].Balance)
+	// creator royalty, matcher fee and same-asset gas must not exceed the buyer's payment
+	sameAssetGas := api.IsZero(api.Sub(tx.GasFeeAssetId, tx.BuyOffer.AssetId))
+	gasCredit := api.Select(sameAssetGas, tx.GasFeeAssetAmount, 0)
+	AssertAtomicMatchConservation(api, tx.BuyOffer.AssetAmount, tx.CreatorAmount, tx.TreasuryAmount, gasCredit)
 	return pubData, nil
 }
@@ -0,0 +1,96 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	oEddsa "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type atomicMatchHashConstraints struct {
+	Tx         AtomicMatchTxConstraints
+	Nonce      Variable
+	ExpiredAt  Variable
+	ExpectHash Variable
+}
+
+func (circuit atomicMatchHashConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hashVal := ComputeHashFromAtomicMatchTx(api, circuit.Tx, circuit.Nonce, circuit.ExpiredAt, hFunc)
+	api.AssertIsEqual(hashVal, circuit.ExpectHash)
+	return nil
+}
+
+func sampleOfferTx(offerType, offerId, accountIndex, nftIndex int64) *OfferTx {
+	var sig oEddsa.Signature
+	sig.R.X.SetUint64(11)
+	sig.R.Y.SetUint64(22)
+	sig.S[0] = 33
+	return &OfferTx{
+		Type:         offerType,
+		OfferId:      offerId,
+		AccountIndex: accountIndex,
+		NftIndex:     nftIndex,
+		AssetId:      0,
+		AssetAmount:  100,
+		ListedAt:     1,
+		ExpiredAt:    1000,
+		TreasuryRate: 200,
+		Sig:          &sig,
+	}
+}
+
+// TestAssertAtomicMatchHashParity checks that the native ComputeAtomicMatchMsgHash
+// and the in-circuit ComputeHashFromAtomicMatchTx agree bit-for-bit on the same
+// inputs, including both offers' raw signature components.
+func TestAssertAtomicMatchHashParity(t *testing.T) {
+	tx := &AtomicMatchTx{
+		AccountIndex:      1,
+		BuyOffer:          sampleOfferTx(0, 1, 2, 3),
+		SellOffer:         sampleOfferTx(1, 2, 3, 3),
+		CreatorAmount:     5,
+		TreasuryAmount:    10,
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: 50,
+	}
+	nonce := int64(7)
+	expiredAt := int64(1000)
+
+	nativeHash := ComputeAtomicMatchMsgHash(tx, nonce, expiredAt)
+
+	assert := test.NewAssert(t)
+	var circuit, witness atomicMatchHashConstraints
+	witness.Tx = SetAtomicMatchTxWitness(tx)
+	witness.Nonce = nonce
+	witness.ExpiredAt = expiredAt
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -17,6 +17,10 @@
 
 package types
 
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
 type CancelOfferTx struct {
 	AccountIndex      int64
 	OfferId           int64
@@ -65,6 +69,19 @@ func ComputeHashFromCancelOfferTx(api API, tx CancelOfferTxConstraints, nonce Va
 	return hashVal
 }
 
+// ComputeCancelOfferMsgHash is the native twin of ComputeHashFromCancelOfferTx:
+// it hashes the same fields in the same order using math/big arithmetic instead
+// of circuit Variables, so the result can be compared against a proving-circuit
+// execution for parity.
+func ComputeCancelOfferMsgHash(tx *CancelOfferTx, nonce int64, expiredAt int64) (hashVal []byte) {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(packInt64VariablesNative(ChainId, tx.AccountIndex, nonce, expiredAt).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.GasAccountIndex, tx.GasFeeAssetId, tx.GasFeeAssetAmount).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.OfferId).FillBytes(make([]byte, 32)))
+	hashVal = hFunc.Sum(nil)
+	return hashVal
+}
+
 func VerifyCancelOfferTx(
 	api API, flag Variable,
 	tx *CancelOfferTxConstraints,
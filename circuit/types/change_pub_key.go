@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+)
+
+// ChangePubKeyTx rotates AccountIndex's eddsa public key to PubKey.
+//
+// Unlike every other tx type, the authorization for this isn't an eddsa
+// signature checked in-circuit: the account's old key obviously can't attest
+// to its own replacement, and the two supported authorization modes - an
+// Ethereum ECDSA/EIP-712 signature, or trust in a prior L1 priority
+// operation - are both checked off-circuit (see wasm/txtypes/change_pub_key.go
+// and VerifyChangePubKeyAuthorization), for the same reason
+// ecc/zp256's doc comment gives for not verifying ECDSA in-circuit at all:
+// this gnark version has no non-native field arithmetic to verify a
+// secp256k1 signature with. AuthMode is carried through to pubdata purely so
+// the emitted record shows which mode authorized the rotation.
+type ChangePubKeyTx struct {
+	AccountIndex int64
+	PubKey       *eddsa.PublicKey
+	AuthMode     int64
+}
+
+// ChangePubKeyTxConstraints is not yet wired into TxConstraints - see
+// VerifyChangePubKeyTx's doc comment - and does not carry the gas-fee fields
+// wasm/txtypes.ChangePubKeyTxInfo has, since nothing here consumes them yet.
+type ChangePubKeyTxConstraints struct {
+	AccountIndex Variable
+	PubKey       PublicKeyConstraints
+	AuthMode     Variable
+}
+
+func EmptyChangePubKeyTxWitness() (witness ChangePubKeyTxConstraints) {
+	return ChangePubKeyTxConstraints{
+		AccountIndex: ZeroInt,
+		PubKey:       EmptyPublicKeyWitness(),
+		AuthMode:     ZeroInt,
+	}
+}
+
+func SetChangePubKeyTxWitness(tx *ChangePubKeyTx) (witness ChangePubKeyTxConstraints) {
+	witness = ChangePubKeyTxConstraints{
+		AccountIndex: tx.AccountIndex,
+		PubKey:       SetPubKeyWitness(tx.PubKey),
+		AuthMode:     tx.AuthMode,
+	}
+	return witness
+}
+
+// VerifyChangePubKeyTx asserts that the account being rotated already
+// exists - the opposite of VerifyRegisterZNSTx's CheckEmptyAccountNode,
+// since this rotates an existing account's key rather than creating a new
+// one - and collects the pubdata recording the new key. It does not touch
+// accountsBefore[0].AccountPk itself; the caller applies the key change via
+// the generic account-update path the same way every other tx-specific
+// delta is applied.
+//
+// Neither this function nor ChangePubKeyTxConstraints is referenced from
+// circuit.TxConstraints or circuit.VerifyTransaction: there is no
+// isChangePubKeyTx flag, no case in VerifyTransaction's pubData dispatch,
+// and no case types.TxTypeChangePubKey in SetTxWitness's switch, unlike
+// every other tx type in this package. A block cannot actually include a
+// ChangePubKeyTx today regardless of how complete this gadget is.
+// ChangePubKeyTxConstraints is also missing the GasAccountIndex,
+// GasFeeAssetId and GasFeeAssetAmount fields wasm/txtypes.ChangePubKeyTxInfo
+// carries, so wiring this in is more than adding a dispatch case: it needs
+// those fields added here, a GetAssetDeltasFromChangePubKey gas-delta helper
+// analogous to GetAssetDeltasFromWithdraw, and nonce-increment handling,
+// before it can be threaded into VerifyTransaction the way RegisterZns is.
+func VerifyChangePubKeyTx(
+	api API, flag Variable,
+	tx ChangePubKeyTxConstraints,
+	accountsBefore [NbAccountsPerTx]AccountConstraints,
+) (pubData [PubDataSizePerTx]Variable) {
+	pubData = CollectPubDataFromChangePubKey(api, tx)
+	CheckNonEmptyAccountNode(api, flag, accountsBefore[0])
+	IsVariableEqual(api, flag, accountsBefore[0].AccountIndex, tx.AccountIndex)
+	return pubData
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"math/big"
+)
+
+// BlockCommitmentNative computes the same digest VerifyBlock asserts
+// block.BlockCommitment against in-circuit: it runs the Keccak256 hint's
+// exact algorithm (32-byte big-endian encode each field, concatenate, hash)
+// natively, so a caller assembling the identical field list off-circuit
+// (BlockNumber, CreatedAt, OldStateRoot, NewStateRoot, each tx's
+// PubDataSizePerTx pubdata words in order, then onChainOpsCount — the same
+// pendingCommitmentData layout VerifyBlock builds) gets a byte-identical
+// commitment without running the circuit. This is what a contract, or an
+// off-chain sequencer preparing a Block for SetBlockWitness, should call to
+// fill in Block.BlockCommitment.
+func BlockCommitmentNative(fields []*big.Int) ([]byte, error) {
+	outputs := []*big.Int{new(big.Int)}
+	if err := Keccak256(0, fields, outputs); err != nil {
+		return nil, err
+	}
+	return outputs[0].FillBytes(make([]byte, 32)), nil
+}
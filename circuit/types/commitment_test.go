@@ -0,0 +1,27 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCommitmentNativeMatchesKeccak256Hint(t *testing.T) {
+	fields := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+
+	got, err := BlockCommitmentNative(fields)
+	require.NoError(t, err)
+
+	want := []*big.Int{new(big.Int)}
+	require.NoError(t, Keccak256(0, fields, want))
+	require.Equal(t, want[0].FillBytes(make([]byte, 32)), got)
+}
+
+func TestBlockCommitmentNativeIsOrderSensitive(t *testing.T) {
+	a, err := BlockCommitmentNative([]*big.Int{big.NewInt(1), big.NewInt(2)})
+	require.NoError(t, err)
+	b, err := BlockCommitmentNative([]*big.Int{big.NewInt(2), big.NewInt(1)})
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+}
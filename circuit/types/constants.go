@@ -66,6 +66,7 @@ const (
 	TxTypeWithdrawNft
 	TxTypeFullExit
 	TxTypeFullExitNft
+	TxTypeChangePubKey
 )
 
 const (
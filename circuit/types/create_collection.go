@@ -17,6 +17,10 @@
 
 package types
 
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
 type CreateCollectionTx struct {
 	AccountIndex      int64
 	CollectionId      int64
@@ -72,6 +76,18 @@ func ComputeHashFromCreateCollectionTx(api API, tx CreateCollectionTxConstraints
 	return hashVal
 }
 
+// ComputeCreateCollectionMsgHash is the native twin of
+// ComputeHashFromCreateCollectionTx: it hashes the same fields in the same
+// order using math/big arithmetic instead of circuit Variables, so the
+// result can be compared against a proving-circuit execution for parity.
+func ComputeCreateCollectionMsgHash(tx *CreateCollectionTx, nonce int64, expiredAt int64) (hashVal []byte) {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(packInt64VariablesNative(ChainId, tx.AccountIndex, nonce, expiredAt).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.GasAccountIndex, tx.GasFeeAssetId, tx.GasFeeAssetAmount).FillBytes(make([]byte, 32)))
+	hashVal = hFunc.Sum(nil)
+	return hashVal
+}
+
 func VerifyCreateCollectionTx(
 	api API, flag Variable,
 	tx *CreateCollectionTxConstraints,
@@ -0,0 +1,74 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type createCollectionHashConstraints struct {
+	Tx         CreateCollectionTxConstraints
+	Nonce      Variable
+	ExpiredAt  Variable
+	ExpectHash Variable
+}
+
+func (circuit createCollectionHashConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hashVal := ComputeHashFromCreateCollectionTx(api, circuit.Tx, circuit.Nonce, circuit.ExpiredAt, hFunc)
+	api.AssertIsEqual(hashVal, circuit.ExpectHash)
+	return nil
+}
+
+// TestAssertCreateCollectionHashParity checks that the native
+// ComputeCreateCollectionMsgHash and the in-circuit
+// ComputeHashFromCreateCollectionTx agree bit-for-bit on the same inputs.
+func TestAssertCreateCollectionHashParity(t *testing.T) {
+	tx := &CreateCollectionTx{
+		AccountIndex:      1,
+		CollectionId:      5,
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: 100,
+		ExpiredAt:         1000,
+	}
+	nonce := int64(7)
+	expiredAt := int64(1000)
+
+	nativeHash := ComputeCreateCollectionMsgHash(tx, nonce, expiredAt)
+
+	assert := test.NewAssert(t)
+	var circuit, witness createCollectionHashConstraints
+	witness.Tx = SetCreateCollectionTxWitness(tx)
+	witness.Nonce = nonce
+	witness.ExpiredAt = expiredAt
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -90,5 +90,9 @@ func VerifyDepositNftTx(
 	IsVariableEqual(api, flag, tx.AccountIndex, accountsBefore[0].AccountIndex)
 	// account name hash
 	IsVariableEqual(api, flag, tx.AccountNameHash, accountsBefore[0].AccountNameHash)
+	// a deposit-nft must carry its L1 provenance: address, token id, and content hash
+	IsVariableDifferent(api, flag, tx.NftL1Address, 0)
+	IsVariableDifferent(api, flag, tx.NftL1TokenId, 0)
+	IsVariableDifferent(api, flag, tx.NftContentHash, 0)
 	return pubData
 }
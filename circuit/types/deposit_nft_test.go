@@ -0,0 +1,102 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type depositNftProvenanceConstraints struct {
+	Tx             DepositNftTxConstraints
+	AccountsBefore [NbAccountsPerTx]AccountConstraints
+	NftBefore      NftConstraints
+}
+
+func (circuit depositNftProvenanceConstraints) Define(api API) error {
+	VerifyDepositNftTx(api, 1, circuit.Tx, circuit.AccountsBefore, circuit.NftBefore)
+	return nil
+}
+
+// TestVerifyDepositNftTxRequiresL1Provenance checks that VerifyDepositNftTx
+// accepts a deposit-nft carrying all three L1 provenance fields (address,
+// token id, content hash), and rejects one missing its L1 address or its
+// token id.
+func TestVerifyDepositNftTxRequiresL1Provenance(t *testing.T) {
+	assetRoot := EmptyAssetRoot.Bytes()
+	accountsBefore := [NbAccountsPerTx]*Account{
+		EmptyAccount(1, assetRoot),
+		EmptyAccount(0, assetRoot),
+		EmptyAccount(0, assetRoot),
+		EmptyAccount(0, assetRoot),
+	}
+	accountsBefore[0].AccountNameHash = []byte{1, 2, 3}
+
+	nftBefore := EmptyNft(7)
+
+	buildWitness := func(tx *DepositNftTx) depositNftProvenanceConstraints {
+		var witness depositNftProvenanceConstraints
+		witness.Tx = SetDepositNftTxWitness(tx)
+		for i, account := range accountsBefore {
+			accountWitness, err := SetAccountWitness(account)
+			if err != nil {
+				t.Fatalf("SetAccountWitness: %v", err)
+			}
+			witness.AccountsBefore[i] = accountWitness
+		}
+		nftWitness, err := SetNftWitness(nftBefore)
+		if err != nil {
+			t.Fatalf("SetNftWitness: %v", err)
+		}
+		witness.NftBefore = nftWitness
+		return witness
+	}
+
+	validTx := &DepositNftTx{
+		AccountIndex:    1,
+		AccountNameHash: []byte{1, 2, 3},
+		NftIndex:        7,
+		NftL1Address:    "1234567890123456789012345678901234567890123456789",
+		NftL1TokenId:    big.NewInt(11),
+		NftContentHash:  []byte{9, 9, 9},
+	}
+
+	assert := test.NewAssert(t)
+	var circuit depositNftProvenanceConstraints
+
+	valid := buildWitness(validTx)
+	assert.SolvingSucceeded(&circuit, &valid, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	missingL1Address := *validTx
+	missingL1Address.NftL1Address = "0"
+	invalid := buildWitness(&missingL1Address)
+	assert.SolvingFailed(&circuit, &invalid, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	missingTokenId := *validTx
+	missingTokenId.NftL1TokenId = big.NewInt(0)
+	invalid = buildWitness(&missingTokenId)
+	assert.SolvingFailed(&circuit, &invalid, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
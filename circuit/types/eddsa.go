@@ -40,13 +40,46 @@ func VerifyEddsaSig(flag Variable, api API, hFunc MiMC, hashVal Variable, pk Pub
 	return nil
 }
 
+// assertInSubGroup rejects points that are off-curve or lie in the small
+// cofactor subgroup, whenever flag is set. curve.ScalarMul's affine formulas
+// carry no implicit on-curve check, so AssertIsOnCurve is asserted first -
+// otherwise a malicious prover could supply an off-curve point for R or A
+// and the subgroup-order multiplication below would say nothing meaningful
+// about it. With the point confirmed on-curve, multiplying it by the
+// subgroup order and asserting the result is the identity rejects low-order
+// points, closing off the leak through the cofactor-cleared equation in
+// verifySignature. When flag is 0 (an empty/padding tx), both checks are
+// skipped so zeroed-out witnesses remain solvable.
+func assertInSubGroup(api frontend.API, flag Variable, curve twistededwards.Curve, p twistededwards.Point) {
+	maskedP := twistededwards.Point{
+		X: api.Select(flag, p.X, 0),
+		Y: api.Select(flag, p.Y, 1),
+	}
+	curve.AssertIsOnCurve(maskedP)
+
+	Q := curve.ScalarMul(p, curve.Params().Order)
+	Q.X = api.Select(flag, Q.X, 0)
+	Q.Y = api.Select(flag, Q.Y, 1)
+	api.AssertIsEqual(Q.X, 0)
+	api.AssertIsEqual(Q.Y, 1)
+}
+
+// WritePointIntoBuf writes a twisted Edwards point into hFunc as its X
+// coordinate followed by its Y coordinate. Every in-circuit hash that
+// absorbs a point (signature R, public key A, ...) must go through this
+// helper so the byte order stays fixed in one place.
+func WritePointIntoBuf(hFunc hash.Hash, p twistededwards.Point) {
+	hFunc.Write(p.X)
+	hFunc.Write(p.Y)
+}
+
 func verifySignature(flag Variable, curve twistededwards.Curve, sig eddsa.Signature, msg frontend.Variable, pubKey PublicKeyConstraints, hash hash.Hash) error {
+	assertInSubGroup(curve.API(), flag, curve, pubKey.A)
+	assertInSubGroup(curve.API(), flag, curve, sig.R)
 
 	// compute H(R, A, M)
-	hash.Write(sig.R.X)
-	hash.Write(sig.R.Y)
-	hash.Write(pubKey.A.X)
-	hash.Write(pubKey.A.Y)
+	WritePointIntoBuf(hash, sig.R)
+	WritePointIntoBuf(hash, pubKey.A)
 	hash.Write(msg)
 	hRAM := hash.Sum()
 
@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	gmimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+type writePointCircuit struct {
+	PX, PY     Variable
+	ExpectHash Variable
+}
+
+func (c writePointCircuit) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	WritePointIntoBuf(&hFunc, twistededwards.Point{X: c.PX, Y: c.PY})
+	api.AssertIsEqual(hFunc.Sum(), c.ExpectHash)
+	return nil
+}
+
+func TestWritePointIntoBufParity(t *testing.T) {
+	p := curve.G
+
+	var buf bytes.Buffer
+	curve.WritePointIntoBufNative(&buf, p)
+	hFunc := gmimc.NewMiMC()
+	hFunc.Write(buf.Bytes())
+	nativeHash := hFunc.Sum(nil)
+
+	px := p.X.ToBigIntRegular(new(big.Int))
+	py := p.Y.ToBigIntRegular(new(big.Int))
+
+	assert := test.NewAssert(t)
+	var circuit, witness writePointCircuit
+	witness.PX = px
+	witness.PY = py
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// ElGamalCiphertextConstraints is the in-circuit counterpart of
+// curve.ElGamalCiphertext: C1 = r*G, C2 = v*G + r*pk.
+type ElGamalCiphertextConstraints struct {
+	C1 twistededwards.Point
+	C2 twistededwards.Point
+}
+
+// SetElGamalCiphertextWitness converts a native ElGamalCiphertext into its
+// circuit witness, the same way SetPubKeyWitness does for an eddsa public key.
+func SetElGamalCiphertextWitness(ciphertext *curve.ElGamalCiphertext) (witness ElGamalCiphertextConstraints) {
+	witness.C1.X = ciphertext.C1.X
+	witness.C1.Y = ciphertext.C1.Y
+	witness.C2.X = ciphertext.C2.X
+	witness.C2.Y = ciphertext.C2.Y
+	return witness
+}
+
+// ReRandomizeCiphertext is the in-circuit twin of curve.ReRandomize: given a
+// ciphertext encrypted under pk, it proves C1' = C1 + r*G and C2' = C2 + r*pk
+// for a prover-supplied randomizer r, without the circuit ever learning the
+// plaintext. A balance-migration circuit constrains its output ciphertext to
+// this gadget's result so a verifier can check the published ciphertext was
+// honestly re-randomized from the old one, instead of trusting the prover to
+// have reused the same plaintext.
+func ReRandomizeCiphertext(curve twistededwards.Curve, pk twistededwards.Point, ciphertext ElGamalCiphertextConstraints, r Variable) ElGamalCiphertextConstraints {
+	base := twistededwards.Point{
+		X: curve.Params().Base[0],
+		Y: curve.Params().Base[1],
+	}
+	rG := curve.ScalarMul(base, r)
+	rPk := curve.ScalarMul(pk, r)
+	return ElGamalCiphertextConstraints{
+		C1: curve.Add(ciphertext.C1, rG),
+		C2: curve.Add(ciphertext.C2, rPk),
+	}
+}
+
+// AssertDualCiphertextsEncryptSameValue proves that userCiphertext (under
+// userPk) and auditorCiphertext (under auditorPk) encrypt the same value,
+// given the prover's knowledge of that value and each ciphertext's own
+// randomizer: it recomputes both ciphertexts from (value, rUser, rAuditor)
+// and asserts the results match the ones passed in. This is what lets an
+// auditor trust that the ciphertext they can decrypt (with their own secret
+// key) carries the same balance as the one the user holds, without the user
+// ever revealing their secret key or the plaintext balance to the circuit's
+// verifier.
+func AssertDualCiphertextsEncryptSameValue(
+	api API,
+	curve twistededwards.Curve,
+	userPk, auditorPk twistededwards.Point,
+	userCiphertext, auditorCiphertext ElGamalCiphertextConstraints,
+	value, rUser, rAuditor Variable,
+) {
+	base := twistededwards.Point{
+		X: curve.Params().Base[0],
+		Y: curve.Params().Base[1],
+	}
+	vG := curve.ScalarMul(base, value)
+
+	wantUserC1 := curve.ScalarMul(base, rUser)
+	wantUserC2 := curve.Add(vG, curve.ScalarMul(userPk, rUser))
+	api.AssertIsEqual(userCiphertext.C1.X, wantUserC1.X)
+	api.AssertIsEqual(userCiphertext.C1.Y, wantUserC1.Y)
+	api.AssertIsEqual(userCiphertext.C2.X, wantUserC2.X)
+	api.AssertIsEqual(userCiphertext.C2.Y, wantUserC2.Y)
+
+	wantAuditorC1 := curve.ScalarMul(base, rAuditor)
+	wantAuditorC2 := curve.Add(vG, curve.ScalarMul(auditorPk, rAuditor))
+	api.AssertIsEqual(auditorCiphertext.C1.X, wantAuditorC1.X)
+	api.AssertIsEqual(auditorCiphertext.C1.Y, wantAuditorC1.Y)
+	api.AssertIsEqual(auditorCiphertext.C2.X, wantAuditorC2.X)
+	api.AssertIsEqual(auditorCiphertext.C2.Y, wantAuditorC2.Y)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/std/algebra/twistededwards"
+	"github.com/consensys/gnark/test"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+type reRandomizeCircuit struct {
+	Pk         twistededwards.Point
+	Ciphertext ElGamalCiphertextConstraints
+	R          Variable
+	Expect     ElGamalCiphertextConstraints
+}
+
+func (c reRandomizeCircuit) Define(api API) error {
+	curveParams, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	result := ReRandomizeCiphertext(curveParams, c.Pk, c.Ciphertext, c.R)
+	api.AssertIsEqual(result.C1.X, c.Expect.C1.X)
+	api.AssertIsEqual(result.C1.Y, c.Expect.C1.Y)
+	api.AssertIsEqual(result.C2.X, c.Expect.C2.X)
+	api.AssertIsEqual(result.C2.Y, c.Expect.C2.Y)
+	return nil
+}
+
+// TestReRandomizeCiphertextParity checks that the native curve.ReRandomize
+// and the in-circuit ReRandomizeCiphertext agree on the same inputs.
+func TestReRandomizeCiphertextParity(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := curve.ScalarBaseMul(sk)
+	ciphertext := curve.ElGamalEncrypt(pk, 42)
+	r := big.NewInt(555)
+	expect := curve.ReRandomize(ciphertext, pk, r)
+
+	assert := test.NewAssert(t)
+	var circuit, witness reRandomizeCircuit
+	witness.Pk = twistededwards.Point{X: pk.X.ToBigIntRegular(new(big.Int)), Y: pk.Y.ToBigIntRegular(new(big.Int))}
+	witness.Ciphertext = SetElGamalCiphertextWitness(ciphertext)
+	witness.R = r
+	witness.Expect = SetElGamalCiphertextWitness(expect)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254))
+}
+
+func pointWitness(p *curve.Point) twistededwards.Point {
+	return twistededwards.Point{X: p.X.ToBigIntRegular(new(big.Int)), Y: p.Y.ToBigIntRegular(new(big.Int))}
+}
+
+type dualCiphertextCircuit struct {
+	UserPk            twistededwards.Point
+	AuditorPk         twistededwards.Point
+	UserCiphertext    ElGamalCiphertextConstraints
+	AuditorCiphertext ElGamalCiphertextConstraints
+	Value             Variable
+	RUser             Variable
+	RAuditor          Variable
+}
+
+func (c dualCiphertextCircuit) Define(api API) error {
+	curveParams, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	AssertDualCiphertextsEncryptSameValue(api, curveParams, c.UserPk, c.AuditorPk, c.UserCiphertext, c.AuditorCiphertext, c.Value, c.RUser, c.RAuditor)
+	return nil
+}
+
+// TestAssertDualCiphertextsEncryptSameValue checks that a prover who knows a
+// value and both ciphertexts' randomizers can prove the two ciphertexts
+// encrypt the same value.
+func TestAssertDualCiphertextsEncryptSameValue(t *testing.T) {
+	userSk := big.NewInt(111)
+	auditorSk := big.NewInt(222)
+	userPk := curve.ScalarBaseMul(userSk)
+	auditorPk := curve.ScalarBaseMul(auditorSk)
+	rUser := big.NewInt(7)
+	rAuditor := big.NewInt(13)
+	dual := curve.DualEncryptWithRandomizers(userPk, auditorPk, 77, rUser, rAuditor)
+
+	assert := test.NewAssert(t)
+	var circuit, witness dualCiphertextCircuit
+	witness.UserPk = pointWitness(userPk)
+	witness.AuditorPk = pointWitness(auditorPk)
+	witness.UserCiphertext = SetElGamalCiphertextWitness(dual.UserCiphertext)
+	witness.AuditorCiphertext = SetElGamalCiphertextWitness(dual.AuditorCiphertext)
+	witness.Value = 77
+	witness.RUser = rUser
+	witness.RAuditor = rAuditor
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254))
+}
@@ -88,6 +88,8 @@ func VerifyFullExitNftTx(
 	IsVariableEqual(api, flag, tx.AccountNameHash, accountsBefore[0].AccountNameHash)
 	IsVariableEqual(api, flag, tx.AccountIndex, accountsBefore[0].AccountIndex)
 	IsVariableEqual(api, flag, tx.NftIndex, nftBefore.NftIndex)
+	// collection id
+	IsVariableEqual(api, flag, tx.CollectionId, nftBefore.CollectionId)
 	isCheck := api.IsZero(api.IsZero(tx.CreatorAccountNameHash))
 	isCheck = api.And(flag, isCheck)
 	IsVariableEqual(api, isCheck, tx.CreatorAccountIndex, nftBefore.CreatorAccountIndex)
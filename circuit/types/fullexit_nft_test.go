@@ -0,0 +1,102 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type fullExitNftCollectionIdConstraints struct {
+	Tx             FullExitNftTxConstraints
+	AccountsBefore [NbAccountsPerTx]AccountConstraints
+	NftBefore      NftConstraints
+}
+
+func (circuit fullExitNftCollectionIdConstraints) Define(api API) error {
+	VerifyFullExitNftTx(api, 1, circuit.Tx, circuit.AccountsBefore, circuit.NftBefore)
+	return nil
+}
+
+// TestVerifyFullExitNftTxRejectsMismatchedCollectionId checks that
+// VerifyFullExitNftTx rejects a full exit whose claimed CollectionId
+// disagrees with the CollectionId already committed on the nft leaf, the
+// same cross-check VerifyWithdrawNftTx already applies.
+func TestVerifyFullExitNftTxRejectsMismatchedCollectionId(t *testing.T) {
+	assetRoot := EmptyAssetRoot.Bytes()
+	account0 := EmptyAccount(1, assetRoot)
+	account0.AccountNameHash = []byte{1, 2, 3}
+	accountsBefore := [NbAccountsPerTx]*Account{
+		account0,
+		EmptyAccount(0, assetRoot),
+		EmptyAccount(0, assetRoot),
+		EmptyAccount(0, assetRoot),
+	}
+
+	nftBefore := EmptyNft(7)
+	nftBefore.CollectionId = 5
+	nftBefore.OwnerAccountIndex = 2
+
+	tx := &FullExitNftTx{
+		AccountIndex:           1,
+		AccountNameHash:        []byte{1, 2, 3},
+		CreatorAccountIndex:    0,
+		CreatorAccountNameHash: []byte{},
+		CreatorTreasuryRate:    0,
+		NftIndex:               7,
+		CollectionId:           5,
+		NftContentHash:         []byte{0},
+		NftL1Address:           "0",
+		NftL1TokenId:           big.NewInt(0),
+	}
+
+	buildWitness := func(claimedCollectionId int64) fullExitNftCollectionIdConstraints {
+		tx.CollectionId = claimedCollectionId
+		var witness fullExitNftCollectionIdConstraints
+		witness.Tx = SetFullExitNftTxWitness(tx)
+		for i, account := range accountsBefore {
+			accountWitness, err := SetAccountWitness(account)
+			if err != nil {
+				t.Fatalf("SetAccountWitness: %v", err)
+			}
+			witness.AccountsBefore[i] = accountWitness
+		}
+		nftWitness, err := SetNftWitness(nftBefore)
+		if err != nil {
+			t.Fatalf("SetNftWitness: %v", err)
+		}
+		witness.NftBefore = nftWitness
+		return witness
+	}
+
+	assert := test.NewAssert(t)
+	var circuit fullExitNftCollectionIdConstraints
+
+	matching := buildWitness(5)
+	assert.SolvingSucceeded(&circuit, &matching, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	mismatched := buildWitness(6)
+	assert.SolvingFailed(&circuit, &mismatched, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
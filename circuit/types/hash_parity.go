@@ -0,0 +1,58 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "fmt"
+
+// HashParityError reports that a native hash and its in-circuit counterpart
+// diverged, naming the tx type, the first byte offset at which they differ,
+// and both full digests so a test failure is debuggable without rerunning
+// under a debugger.
+type HashParityError struct {
+	TxType      string
+	Offset      int
+	NativeHash  []byte
+	CircuitHash []byte
+}
+
+func (e *HashParityError) Error() string {
+	return fmt.Sprintf("%s hash parity failure at byte offset %d: native=%x circuit=%x",
+		e.TxType, e.Offset, e.NativeHash, e.CircuitHash)
+}
+
+// AssertHashParity compares a native tx hash against the digest produced by
+// the equivalent in-circuit hash gadget, returning a *HashParityError naming
+// the first differing byte offset when they disagree (including a length
+// mismatch, reported at the shorter digest's length). It is a test-time
+// helper, the in-circuit equivalent of the byte-for-byte checks
+// ValidateWitnessFieldElements runs over witness values.
+func AssertHashParity(txType string, nativeHash, circuitHash []byte) error {
+	n := len(nativeHash)
+	if len(circuitHash) < n {
+		n = len(circuitHash)
+	}
+	for i := 0; i < n; i++ {
+		if nativeHash[i] != circuitHash[i] {
+			return &HashParityError{TxType: txType, Offset: i, NativeHash: nativeHash, CircuitHash: circuitHash}
+		}
+	}
+	if len(nativeHash) != len(circuitHash) {
+		return &HashParityError{TxType: txType, Offset: n, NativeHash: nativeHash, CircuitHash: circuitHash}
+	}
+	return nil
+}
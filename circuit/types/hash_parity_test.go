@@ -0,0 +1,43 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertHashParity(t *testing.T) {
+	matching := []byte{1, 2, 3, 4, 5}
+	require.NoError(t, AssertHashParity("Transfer", matching, append([]byte{}, matching...)))
+
+	mismatched := []byte{1, 2, 9, 4, 5}
+	err := AssertHashParity("Transfer", matching, mismatched)
+	require.Error(t, err)
+	var parityErr *HashParityError
+	require.ErrorAs(t, err, &parityErr)
+	require.Equal(t, 2, parityErr.Offset)
+	require.Equal(t, "Transfer", parityErr.TxType)
+
+	shorter := matching[:3]
+	err = AssertHashParity("Transfer", matching, shorter)
+	require.Error(t, err)
+	require.ErrorAs(t, err, &parityErr)
+	require.Equal(t, 3, parityErr.Offset)
+}
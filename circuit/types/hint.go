@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -18,3 +19,22 @@ func Keccak256(_ ecc.ID, inputs []*big.Int, outputs []*big.Int) error {
 	result.SetBytes(hashVal[:])
 	return nil
 }
+
+// DivFloor computes the quotient and remainder of inputs[0] / inputs[1]
+// (floor division, for non-negative inputs), so a circuit can constrain an
+// exact integer division without gnark's field-inverse api.Div, which only
+// enforces divisibility modulo the scalar field rather than over the
+// integers.
+func DivFloor(_ ecc.ID, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 2 {
+		return fmt.Errorf("DivFloor expects 2 inputs, got %d", len(inputs))
+	}
+	if inputs[1].Sign() == 0 {
+		return fmt.Errorf("DivFloor: division by zero")
+	}
+	quotient, remainder := new(big.Int), new(big.Int)
+	quotient.DivMod(inputs[0], inputs[1], remainder)
+	outputs[0].Set(quotient)
+	outputs[1].Set(remainder)
+	return nil
+}
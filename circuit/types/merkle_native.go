@@ -0,0 +1,38 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// MerkleHelperFromIndex is the native twin of AccountIndexToMerkleHelper/
+// AssetIdToMerkleHelper/NftIndexToMerkleHelper: index's bits, least
+// significant bit first, padded/truncated to levels bits. Each bit selects
+// whether the corresponding proofSet entry in UpdateMerkleProofNative is the
+// left or right sibling at that height.
+func MerkleHelperFromIndex(index int64, levels int) []int {
+	helper := make([]int, levels)
+	for i := 0; i < levels; i++ {
+		helper[i] = int((index >> uint(i)) & 1)
+	}
+	return helper
+}
+
+// UpdateMerkleProofNative is the native twin of UpdateMerkleProof: it folds
+// leaf up through proofSet using helper to pick left/right order at each
+// height (mirroring nodeSum's api.Select), returning the resulting root.
+func UpdateMerkleProofNative(leaf []byte, proofSet [][]byte, helper []int) []byte {
+	node := leaf
+	for i := 0; i < len(proofSet); i++ {
+		d1, d2 := node, proofSet[i]
+		if helper[i] == 1 {
+			d1, d2 = proofSet[i], node
+		}
+		hFunc := mimc.NewMiMC()
+		hFunc.Write(new(big.Int).SetBytes(d1).FillBytes(make([]byte, 32)))
+		hFunc.Write(new(big.Int).SetBytes(d2).FillBytes(make([]byte, 32)))
+		node = hFunc.Sum(nil)
+	}
+	return node
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type updateMerkleProofConstraints struct {
+	Leaf       Variable
+	ProofSet   [4]Variable
+	Helper     [4]Variable
+	ExpectRoot Variable
+}
+
+func (circuit updateMerkleProofConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	root := UpdateMerkleProof(api, hFunc, circuit.Leaf, circuit.ProofSet[:], circuit.Helper[:])
+	api.AssertIsEqual(root, circuit.ExpectRoot)
+	return nil
+}
+
+// TestUpdateMerkleProofNativeMatchesCircuit checks that UpdateMerkleProofNative
+// folds a leaf up through a proof set the same way the in-circuit
+// UpdateMerkleProof does, for both left and right sibling placements.
+func TestUpdateMerkleProofNativeMatchesCircuit(t *testing.T) {
+	leaf := big.NewInt(42).FillBytes(make([]byte, 32))
+	proofSet := [][]byte{
+		big.NewInt(1).FillBytes(make([]byte, 32)),
+		big.NewInt(2).FillBytes(make([]byte, 32)),
+		big.NewInt(3).FillBytes(make([]byte, 32)),
+		big.NewInt(4).FillBytes(make([]byte, 32)),
+	}
+	helper := []int{1, 0, 1, 0}
+
+	nativeRoot := UpdateMerkleProofNative(leaf, proofSet, helper)
+
+	assert := test.NewAssert(t)
+	var circuit updateMerkleProofConstraints
+	witness := updateMerkleProofConstraints{
+		Leaf:       new(big.Int).SetBytes(leaf),
+		ExpectRoot: new(big.Int).SetBytes(nativeRoot),
+	}
+	for i := range proofSet {
+		witness.ProofSet[i] = new(big.Int).SetBytes(proofSet[i])
+		witness.Helper[i] = helper[i]
+	}
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+// MerkleVerifier wraps a single MiMC hasher for callers that verify and
+// update several Merkle proofs back to back, the way VerifyGas and
+// VerifyTransaction do once per account/asset/nft leaf a tx touches: today
+// each of those call sites interleaves its own hFunc.Reset() between every
+// VerifyMerkleProof/UpdateMerkleProof call, which is easy to get wrong (a
+// missing Reset silently chains the hasher's Miyaguchi-Preneel state into
+// the next leaf instead of starting fresh). MerkleVerifier resets the
+// hasher itself, so callers can't forget to.
+//
+// "Batched" here means "run one after another with Reset handled for you,"
+// not concurrent: a single circuit Define() builds one R1CS sequentially,
+// so there is no goroutine-level parallelism to add inside it.
+type MerkleVerifier struct {
+	api API
+	h   MiMC
+}
+
+// NewMerkleVerifier wraps h for use by a single VerifyTransaction/VerifyGas
+// call. h is reset before every proof, so its state going in doesn't
+// matter.
+func NewMerkleVerifier(api API, h MiMC) *MerkleVerifier {
+	return &MerkleVerifier{api: api, h: h}
+}
+
+// Verify resets the wrapped hasher and calls VerifyMerkleProof.
+func (v *MerkleVerifier) Verify(isEnabled Variable, merkleRoot, node Variable, proofSet, helper []Variable) {
+	v.h.Reset()
+	VerifyMerkleProof(v.api, isEnabled, v.h, merkleRoot, node, proofSet, helper)
+}
+
+// Update resets the wrapped hasher and calls UpdateMerkleProof.
+func (v *MerkleVerifier) Update(node Variable, proofSet, helper []Variable) Variable {
+	v.h.Reset()
+	return UpdateMerkleProof(v.api, v.h, node, proofSet, helper)
+}
+
+// VerifyAndUpdate is the pair that shows up once per leaf across VerifyGas
+// and VerifyTransaction: verify beforeNode against merkleRoot along
+// proofSet/helper, then fold afterNode into the same proofSet/helper to
+// produce the new root.
+func (v *MerkleVerifier) VerifyAndUpdate(isEnabled Variable, merkleRoot, beforeNode, afterNode Variable, proofSet, helper []Variable) Variable {
+	v.Verify(isEnabled, merkleRoot, beforeNode, proofSet, helper)
+	return v.Update(afterNode, proofSet, helper)
+}
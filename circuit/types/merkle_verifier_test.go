@@ -0,0 +1,65 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type merkleVerifierConstraints struct {
+	BeforeLeaf Variable
+	AfterLeaf  Variable
+	Root       Variable
+	ProofSet   [4]Variable
+	Helper     [4]Variable
+	ExpectRoot Variable
+}
+
+func (circuit merkleVerifierConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	verifier := NewMerkleVerifier(api, hFunc)
+	newRoot := verifier.VerifyAndUpdate(1, circuit.Root, circuit.BeforeLeaf, circuit.AfterLeaf, circuit.ProofSet[:], circuit.Helper[:])
+	api.AssertIsEqual(newRoot, circuit.ExpectRoot)
+	return nil
+}
+
+// TestMerkleVerifierVerifyAndUpdateMatchesManualResetSequence checks that
+// MerkleVerifier.VerifyAndUpdate produces the same root as the manual
+// Reset/VerifyMerkleProof/Reset/UpdateMerkleProof sequence it replaces.
+func TestMerkleVerifierVerifyAndUpdateMatchesManualResetSequence(t *testing.T) {
+	beforeLeaf := big.NewInt(42).FillBytes(make([]byte, 32))
+	afterLeaf := big.NewInt(43).FillBytes(make([]byte, 32))
+	proofSet := [][]byte{
+		big.NewInt(1).FillBytes(make([]byte, 32)),
+		big.NewInt(2).FillBytes(make([]byte, 32)),
+		big.NewInt(3).FillBytes(make([]byte, 32)),
+		big.NewInt(4).FillBytes(make([]byte, 32)),
+	}
+	helper := []int{1, 0, 1, 0}
+	root := UpdateMerkleProofNative(beforeLeaf, proofSet, helper)
+	expectRoot := UpdateMerkleProofNative(afterLeaf, proofSet, helper)
+
+	assert := test.NewAssert(t)
+	var circuit merkleVerifierConstraints
+	witness := merkleVerifierConstraints{
+		BeforeLeaf: new(big.Int).SetBytes(beforeLeaf),
+		AfterLeaf:  new(big.Int).SetBytes(afterLeaf),
+		Root:       new(big.Int).SetBytes(root),
+		ExpectRoot: new(big.Int).SetBytes(expectRoot),
+	}
+	for i := range proofSet {
+		witness.ProofSet[i] = new(big.Int).SetBytes(proofSet[i])
+		witness.Helper[i] = helper[i]
+	}
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -17,6 +17,12 @@
 
 package types
 
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
 type MintNftTx struct {
 	CreatorAccountIndex int64
 	ToAccountIndex      int64
@@ -91,6 +97,32 @@ func ComputeHashFromMintNftTx(api API, tx MintNftTxConstraints, nonce Variable,
 	return hashVal
 }
 
+// packInt64VariablesNative is the native (math/big) equivalent of PackInt64Variables,
+// used to keep native hash computations bit-for-bit aligned with the circuit.
+func packInt64VariablesNative(inputs ...int64) *big.Int {
+	shift := new(big.Int).Exp(big.NewInt(2), big.NewInt(64), nil)
+	res := new(big.Int).SetInt64(inputs[0])
+	for _, input := range inputs[1:] {
+		res = new(big.Int).Mul(res, shift)
+		res = new(big.Int).Add(res, big.NewInt(input))
+	}
+	return res
+}
+
+// ComputeMintNftMsgHash is the native twin of ComputeHashFromMintNftTx: it hashes the
+// same fields in the same order using math/big arithmetic instead of circuit Variables,
+// so the result can be compared against a proving-circuit execution for parity.
+func ComputeMintNftMsgHash(tx *MintNftTx, nonce int64, expiredAt int64) (hashVal []byte) {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(packInt64VariablesNative(ChainId, tx.CreatorAccountIndex, nonce, expiredAt).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.GasAccountIndex, tx.GasFeeAssetId, tx.GasFeeAssetAmount).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.ToAccountIndex, tx.CreatorTreasuryRate, tx.CollectionId).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(tx.ToAccountNameHash).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(tx.NftContentHash).FillBytes(make([]byte, 32)))
+	hashVal = hFunc.Sum(nil)
+	return hashVal
+}
+
 func VerifyMintNftTx(
 	api API, flag Variable,
 	tx *MintNftTxConstraints,
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// MintNftNode builds the Nft leaf that VerifyMintNftTx's caller (tx_constraints.go)
+// inserts into the nft tree once a mint succeeds: an empty nft slot (checked by
+// CheckEmptyNftNode) becomes an owned nft with no L1 presence yet.
+func MintNftNode(tx *MintNftTx) *Nft {
+	return &Nft{
+		NftIndex:            tx.NftIndex,
+		NftContentHash:      tx.NftContentHash,
+		CreatorAccountIndex: tx.CreatorAccountIndex,
+		OwnerAccountIndex:   tx.ToAccountIndex,
+		NftL1Address:        big.NewInt(0),
+		NftL1TokenId:        big.NewInt(0),
+		CreatorTreasuryRate: tx.CreatorTreasuryRate,
+		CollectionId:        tx.CollectionId,
+	}
+}
+
+// NftNodeHash is the native twin of the nft leaf hash tx_constraints.go computes
+// in-circuit when reading or updating a nft tree node: mimc over the same fields
+// in the same order, so a witness builder can derive the post-mint nft root
+// without running the circuit.
+func NftNodeHash(nft *Nft) []byte {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(big.NewInt(nft.CreatorAccountIndex).FillBytes(make([]byte, 32)))
+	hFunc.Write(big.NewInt(nft.OwnerAccountIndex).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(nft.NftContentHash).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).Set(nft.NftL1Address).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).Set(nft.NftL1TokenId).FillBytes(make([]byte, 32)))
+	hFunc.Write(big.NewInt(nft.CreatorTreasuryRate).FillBytes(make([]byte, 32)))
+	hFunc.Write(big.NewInt(nft.CollectionId).FillBytes(make([]byte, 32)))
+	return hFunc.Sum(nil)
+}
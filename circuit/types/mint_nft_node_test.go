@@ -0,0 +1,73 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type nftNodeHashConstraints struct {
+	Nft        NftConstraints
+	ExpectHash Variable
+}
+
+func (circuit nftNodeHashConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hFunc.Write(
+		circuit.Nft.CreatorAccountIndex,
+		circuit.Nft.OwnerAccountIndex,
+		circuit.Nft.NftContentHash,
+		circuit.Nft.NftL1Address,
+		circuit.Nft.NftL1TokenId,
+		circuit.Nft.CreatorTreasuryRate,
+		circuit.Nft.CollectionId,
+	)
+	api.AssertIsEqual(hFunc.Sum(), circuit.ExpectHash)
+	return nil
+}
+
+// TestMintNftNodeMatchesCircuitLeafHash checks that NftNodeHash(MintNftNode(tx))
+// matches the nft leaf hash the circuit computes when it inserts the minted nft
+// into the nft tree, i.e. that a witness builder using these native helpers
+// would arrive at the same nft root the circuit does.
+func TestMintNftNodeMatchesCircuitLeafHash(t *testing.T) {
+	tx := &MintNftTx{
+		CreatorAccountIndex: 1,
+		ToAccountIndex:      2,
+		ToAccountNameHash:   []byte{0x01, 0x02, 0x03},
+		NftIndex:            3,
+		NftContentHash:      []byte{0x04, 0x05, 0x06},
+		CreatorTreasuryRate: 200,
+		GasAccountIndex:     4,
+		GasFeeAssetId:       0,
+		GasFeeAssetAmount:   100,
+		CollectionId:        5,
+		ExpiredAt:           1000,
+	}
+
+	node := MintNftNode(tx)
+	nativeHash := NftNodeHash(node)
+
+	witness, err := SetNftWitness(node)
+	if err != nil {
+		t.Fatalf("SetNftWitness failed: %v", err)
+	}
+
+	assert := test.NewAssert(t)
+	var circuit nftNodeHashConstraints
+	circuitWitness := nftNodeHashConstraints{
+		Nft:        witness,
+		ExpectHash: new(big.Int).SetBytes(nativeHash),
+	}
+	assert.SolvingSucceeded(
+		&circuit, &circuitWitness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type mintNftHashConstraints struct {
+	Tx         MintNftTxConstraints
+	Nonce      Variable
+	ExpiredAt  Variable
+	ExpectHash Variable
+}
+
+func (circuit mintNftHashConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hashVal := ComputeHashFromMintNftTx(api, circuit.Tx, circuit.Nonce, circuit.ExpiredAt, hFunc)
+	api.AssertIsEqual(hashVal, circuit.ExpectHash)
+	return nil
+}
+
+// AssertMintNftHashParity checks that the native ComputeMintNftMsgHash and the in-circuit
+// ComputeHashFromMintNftTx agree bit-for-bit on the same inputs.
+func TestAssertMintNftHashParity(t *testing.T) {
+	tx := &MintNftTx{
+		CreatorAccountIndex: 1,
+		ToAccountIndex:      2,
+		ToAccountNameHash:   []byte{0x01, 0x02, 0x03},
+		NftIndex:            3,
+		NftContentHash:      []byte{0x04, 0x05, 0x06},
+		CreatorTreasuryRate: 200,
+		GasAccountIndex:     4,
+		GasFeeAssetId:       0,
+		GasFeeAssetAmount:   100,
+		CollectionId:        5,
+		ExpiredAt:           1000,
+	}
+	nonce := int64(7)
+	expiredAt := int64(1000)
+
+	nativeHash := ComputeMintNftMsgHash(tx, nonce, expiredAt)
+
+	assert := test.NewAssert(t)
+	var circuit, witness mintNftHashConstraints
+	witness.Tx = SetMintNftTxWitness(tx)
+	witness.Nonce = nonce
+	witness.ExpiredAt = expiredAt
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "math/big"
+
+// Poseidon is the in-circuit twin of PoseidonNative (poseidon_native.go):
+// same Write/Sum/Reset shape as gnark's std/hash/mimc.MiMC, so call sites
+// can switch between the two the same way UpdateMerkleProof already takes
+// its hash function as a parameter. Sum compresses the written data two
+// elements at a time, folding the running digest in as the left input of
+// the next pair, the same left-to-right chaining UpdateMerkleProof uses.
+type Poseidon struct {
+	api  API
+	data []Variable
+}
+
+// NewPoseidon returns a Poseidon gadget bound to api, than can be used in a
+// gnark circuit.
+func NewPoseidon(api API) Poseidon {
+	return Poseidon{api: api}
+}
+
+// Write adds more data to the running hash.
+func (h *Poseidon) Write(data ...Variable) {
+	h.data = append(h.data, data...)
+}
+
+// Reset resets the Hash to its initial state.
+func (h *Poseidon) Reset() {
+	h.data = nil
+}
+
+// Sum folds the written data through the Poseidon permutation two elements
+// at a time and returns the resulting digest.
+func (h *Poseidon) Sum() Variable {
+	if len(h.data) == 0 {
+		return 0
+	}
+	digest := h.data[0]
+	for i := 1; i < len(h.data); i++ {
+		digest = poseidonCompress(h.api, digest, h.data[i])
+	}
+	h.data = nil
+	return digest
+}
+
+// poseidonCompress runs one Poseidon permutation over (left, right, 0) and
+// returns state[0], mirroring PoseidonNative's 2-to-1 compression.
+func poseidonCompress(api API, left, right Variable) Variable {
+	state := [poseidonWidth]Variable{left, right, 0}
+
+	halfFull := poseidonFullRounds / 2
+	round := 0
+	applyRound := func(full bool) {
+		for i := 0; i < poseidonWidth; i++ {
+			state[i] = api.Add(state[i], constantToBigInt(round, i))
+		}
+		if full {
+			for i := 0; i < poseidonWidth; i++ {
+				state[i] = poseidonSBoxConstraints(api, state[i])
+			}
+		} else {
+			state[0] = poseidonSBoxConstraints(api, state[0])
+		}
+		var next [poseidonWidth]Variable
+		for i := 0; i < poseidonWidth; i++ {
+			var acc Variable = 0
+			for j := 0; j < poseidonWidth; j++ {
+				acc = api.Add(acc, api.Mul(mdsToBigInt(i, j), state[j]))
+			}
+			next[i] = acc
+		}
+		state = next
+		round++
+	}
+	for i := 0; i < halfFull; i++ {
+		applyRound(true)
+	}
+	for i := 0; i < poseidonPartRounds; i++ {
+		applyRound(false)
+	}
+	for i := 0; i < halfFull; i++ {
+		applyRound(true)
+	}
+	return state[0]
+}
+
+// poseidonSBoxConstraints raises x to poseidonSBoxDegree (5) via two
+// squarings and one multiplication, matching poseidonSBox's native x^5.
+func poseidonSBoxConstraints(api API, x Variable) Variable {
+	x2 := api.Mul(x, x)
+	x4 := api.Mul(x2, x2)
+	return api.Mul(x4, x)
+}
+
+func constantToBigInt(round, i int) *big.Int {
+	var z = poseidonRoundConstants[round][i]
+	b := z.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+func mdsToBigInt(i, j int) *big.Int {
+	var z = poseidonMds[i][j]
+	b := z.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
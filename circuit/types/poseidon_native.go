@@ -0,0 +1,157 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// Poseidon is a 2-to-1 compression function over BN254's scalar field,
+// offered as a lower-constraint-count alternative to MiMC for state trees
+// and tx hashes. Neither gnark nor gnark-crypto at the version this repo is
+// pinned to ships a Poseidon implementation or a published BN254 parameter
+// set, so poseidonRoundConstants/poseidonMds below are this package's own:
+// round constants are expanded deterministically from a fixed seed string
+// via the native MiMC this repo already depends on (so there is no new
+// hash primitive to trust, only Poseidon's sponge/permutation structure
+// built on top of it), and the MDS matrix is a Cauchy matrix, which is MDS
+// for any choice of distinct, pairwise-disjoint x/y values. This mirrors
+// how UpdateMerkleProofNative (merkle_native.go) is the native twin of the
+// in-circuit UpdateMerkleProof: PoseidonNative here is the native twin of
+// the in-circuit Poseidon gadget in poseidon.go, and the two are tested
+// against each other directly.
+const (
+	poseidonWidth       = 3 // t: rate 2 (the two inputs being compressed) + capacity 1
+	poseidonFullRounds  = 8
+	poseidonPartRounds  = 57
+	poseidonSBoxDegree  = 5
+	poseidonConstSeed   = "zkbnb-crypto/poseidon/bn254/constants"
+	poseidonMdsXYOffset = 1
+)
+
+var (
+	poseidonRoundConstants [][poseidonWidth]fr.Element
+	poseidonMds            [poseidonWidth][poseidonWidth]fr.Element
+)
+
+func init() {
+	poseidonRoundConstants = poseidonExpandRoundConstants(poseidonFullRounds + poseidonPartRounds)
+	poseidonMds = poseidonCauchyMds()
+}
+
+// poseidonExpandRoundConstants derives nbRounds*poseidonWidth field elements
+// from poseidonConstSeed by hashing an incrementing counter with MiMC. This
+// is a standard way to turn an auditable seed string into "nothing up my
+// sleeve" constants when no reference parameter set is available.
+func poseidonExpandRoundConstants(nbRounds int) [][poseidonWidth]fr.Element {
+	constants := make([][poseidonWidth]fr.Element, nbRounds)
+	counter := uint64(0)
+	for round := 0; round < nbRounds; round++ {
+		for j := 0; j < poseidonWidth; j++ {
+			hFunc := mimc.NewMiMC()
+			hFunc.Write([]byte(poseidonConstSeed))
+			hFunc.Write(new(big.Int).SetUint64(counter).FillBytes(make([]byte, 32)))
+			counter++
+			constants[round][j].SetBytes(hFunc.Sum(nil))
+		}
+	}
+	return constants
+}
+
+// poseidonCauchyMds builds the poseidonWidth x poseidonWidth Cauchy matrix
+// M[i][j] = 1/(x_i - y_j) for x_i = i, y_j = poseidonWidth + poseidonMdsXYOffset + j.
+// A Cauchy matrix built from two disjoint sets of distinct field elements is
+// always MDS (every square submatrix is invertible).
+func poseidonCauchyMds() [poseidonWidth][poseidonWidth]fr.Element {
+	var mds [poseidonWidth][poseidonWidth]fr.Element
+	for i := 0; i < poseidonWidth; i++ {
+		x := fr.NewElement(uint64(i))
+		for j := 0; j < poseidonWidth; j++ {
+			y := fr.NewElement(uint64(poseidonWidth + poseidonMdsXYOffset + j))
+			var denom fr.Element
+			denom.Sub(&x, &y)
+			mds[i][j].Inverse(&denom)
+		}
+	}
+	return mds
+}
+
+func poseidonSBox(x *fr.Element) fr.Element {
+	var out fr.Element
+	out.Exp(*x, big.NewInt(poseidonSBoxDegree))
+	return out
+}
+
+// poseidonPermute runs the Poseidon permutation in place over state, full
+// S-box rounds first, then partial rounds (S-box on state[0] only), then
+// the remaining full rounds, with the MDS matrix mixing every round.
+func poseidonPermute(state *[poseidonWidth]fr.Element) {
+	halfFull := poseidonFullRounds / 2
+	round := 0
+	applyRound := func(full bool) {
+		for i := 0; i < poseidonWidth; i++ {
+			state[i].Add(&state[i], &poseidonRoundConstants[round][i])
+		}
+		if full {
+			for i := 0; i < poseidonWidth; i++ {
+				state[i] = poseidonSBox(&state[i])
+			}
+		} else {
+			state[0] = poseidonSBox(&state[0])
+		}
+		var next [poseidonWidth]fr.Element
+		for i := 0; i < poseidonWidth; i++ {
+			var acc fr.Element
+			for j := 0; j < poseidonWidth; j++ {
+				var term fr.Element
+				term.Mul(&poseidonMds[i][j], &state[j])
+				acc.Add(&acc, &term)
+			}
+			next[i] = acc
+		}
+		*state = next
+		round++
+	}
+	for i := 0; i < halfFull; i++ {
+		applyRound(true)
+	}
+	for i := 0; i < poseidonPartRounds; i++ {
+		applyRound(false)
+	}
+	for i := 0; i < halfFull; i++ {
+		applyRound(true)
+	}
+}
+
+// PoseidonNative compresses two field elements (given as big-endian byte
+// slices, the same calling convention UpdateMerkleProofNative and the
+// native MiMC helpers in this package use) to one, via the permutation
+// above with capacity element 0. It is the native twin of the in-circuit
+// Poseidon gadget in poseidon.go.
+func PoseidonNative(left, right []byte) []byte {
+	var state [poseidonWidth]fr.Element
+	state[0].SetBytes(left)
+	state[1].SetBytes(right)
+	// state[2] (the capacity lane) stays zero.
+	poseidonPermute(&state)
+	digest := state[0].Bytes()
+	return digest[:]
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ComputePubDataCommitment computes the pubdata commitment for a block the
+// same way the Keccak256 hint does in-circuit: the pubdata bytes of each tx,
+// in block order, are concatenated and hashed with a single Keccak256. It
+// lets an operator recompute the pubdata portion of a block's commitment
+// off-chain, from the same per-tx pubdata bytes that were fed into the
+// circuit, to check it against the value posted on L1.
+func ComputePubDataCommitment(txs [][]byte) []byte {
+	preImage := make([]byte, 0)
+	for _, tx := range txs {
+		preImage = append(preImage, tx...)
+	}
+	hashVal := crypto.Keccak256Hash(preImage)
+	return hashVal.Bytes()
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// TestComputePubDataCommitmentKnownAnswer pins the expected hash for a small,
+// fixed block so a future change to the hashing scheme is caught by this
+// test rather than silently diverging from the in-circuit Keccak256 hint.
+func TestComputePubDataCommitmentKnownAnswer(t *testing.T) {
+	txs := [][]byte{
+		{1, 2, 3},
+		{4, 5, 6, 7},
+	}
+	expected := crypto.Keccak256Hash([]byte{1, 2, 3, 4, 5, 6, 7}).Bytes()
+	require.Equal(t, expected, ComputePubDataCommitment(txs))
+}
+
+func TestComputePubDataCommitmentEmpty(t *testing.T) {
+	expected := crypto.Keccak256Hash([]byte{}).Bytes()
+	require.Equal(t, expected, ComputePubDataCommitment(nil))
+}
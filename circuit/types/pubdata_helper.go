@@ -37,6 +37,25 @@ func CollectPubDataFromRegisterZNS(api API, txInfo RegisterZnsTxConstraints) (pu
 	return pubData
 }
 
+func CollectPubDataFromChangePubKey(api API, txInfo ChangePubKeyTxConstraints) (pubData [PubDataSizePerTx]Variable) {
+	txTypeBits := api.ToBinary(TxTypeChangePubKey, TxTypeBitsSize)
+	accountIndexBits := api.ToBinary(txInfo.AccountIndex, AccountIndexBitsSize)
+	ABits := append(accountIndexBits, txTypeBits...)
+	var paddingSize [216]Variable
+	for i := 0; i < 216; i++ {
+		paddingSize[i] = 0
+	}
+	ABits = append(paddingSize[:], ABits...)
+	pubData[0] = api.FromBinary(ABits...)
+	pubData[1] = txInfo.AuthMode
+	pubData[2] = txInfo.PubKey.A.X
+	pubData[3] = txInfo.PubKey.A.Y
+	for i := 4; i < PubDataSizePerTx; i++ {
+		pubData[i] = 0
+	}
+	return pubData
+}
+
 func CollectPubDataFromDeposit(api API, txInfo DepositTxConstraints) (pubData [PubDataSizePerTx]Variable) {
 	txTypeBits := api.ToBinary(TxTypeDeposit, TxTypeBitsSize)
 	accountIndexBits := api.ToBinary(txInfo.AccountIndex, AccountIndexBitsSize)
@@ -0,0 +1,50 @@
+package types
+
+import "math/big"
+
+// ComputePubDataKeccak assembles a block's pubdata fields in the exact
+// order VerifyBlock feeds into the Keccak256 hint (BlockNumber, CreatedAt,
+// OldStateRoot, NewStateRoot, each tx's PubDataSizePerTx words in order,
+// then onChainOpsCount) and hashes them with BlockCommitmentNative, so a
+// caller holding a block's already-computed per-tx pubdata doesn't have to
+// hand-flatten it into the layout VerifyBlock expects. The result matches
+// Solidity's keccak256 directly (BlockCommitmentNative calls the same
+// go-ethereum crypto.Keccak256Hash a Solidity verifier contract's keccak256
+// opcode implements), so no MiMC-to-keccak bridge is needed on the contract
+// side to check it.
+//
+// Note on the in-circuit half of this: there is no bit-accurate Keccak-f
+// permutation gadget under circuit/bn254/std, and none is added here.
+// gnark v0.7.0's std library ships no keccak/sha3 gadget to build on, and
+// arithmetizing Keccak-f[1600] (24 rounds of theta/rho/pi/chi/iota over
+// 1600 bits) from scratch is a large, security-critical primitive that
+// would need independent audit before it belongs in a circuit whose job is
+// proving correctness — not something to freehand in a single change. The
+// existing mechanism (api.Compiler().NewHint(Keccak256, ...) in
+// VerifyBlock) already produces this same Solidity-compatible digest as an
+// unconstrained hint, with soundness resting on the on-chain verifier
+// independently recomputing and checking it — the standard "hint plus
+// external check" pattern for hash functions that are expensive to
+// arithmetize.
+func ComputePubDataKeccak(
+	blockNumber, createdAt int64,
+	oldStateRoot, newStateRoot []byte,
+	txPubData [][PubDataSizePerTx]*big.Int,
+	onChainOpsCount int64,
+) ([]byte, error) {
+	fields := make([]*big.Int, 0, 4+PubDataSizePerTx*len(txPubData)+1)
+	fields = append(fields,
+		big.NewInt(blockNumber),
+		big.NewInt(createdAt),
+		new(big.Int).SetBytes(oldStateRoot),
+		new(big.Int).SetBytes(newStateRoot),
+	)
+	for _, pubData := range txPubData {
+		for i := 0; i < PubDataSizePerTx; i++ {
+			fields = append(fields, pubData[i])
+		}
+	}
+	fields = append(fields, big.NewInt(onChainOpsCount))
+
+	return BlockCommitmentNative(fields)
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputePubDataKeccakMatchesBlockCommitmentNative(t *testing.T) {
+	oldRoot := big.NewInt(11).FillBytes(make([]byte, 32))
+	newRoot := big.NewInt(22).FillBytes(make([]byte, 32))
+	txPubData := [][PubDataSizePerTx]*big.Int{
+		{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5), big.NewInt(6)},
+		{big.NewInt(7), big.NewInt(8), big.NewInt(9), big.NewInt(10), big.NewInt(11), big.NewInt(12)},
+	}
+
+	got, err := ComputePubDataKeccak(100, 200, oldRoot, newRoot, txPubData, 2)
+	require.NoError(t, err)
+
+	fields := []*big.Int{big.NewInt(100), big.NewInt(200), big.NewInt(11), big.NewInt(22)}
+	for _, pubData := range txPubData {
+		fields = append(fields, pubData[:]...)
+	}
+	fields = append(fields, big.NewInt(2))
+	want, err := BlockCommitmentNative(fields)
+	require.NoError(t, err)
+
+	require.Equal(t, want, got)
+}
+
+func TestComputePubDataKeccakNoTxsStillHashesBlockFields(t *testing.T) {
+	root := big.NewInt(1).FillBytes(make([]byte, 32))
+	got, err := ComputePubDataKeccak(1, 1, root, root, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 32)
+}
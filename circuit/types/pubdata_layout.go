@@ -0,0 +1,229 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+// FieldSpec describes one named, fixed-width field packed into a pubData
+// word by the corresponding CollectPubDataFromXxx function. Fields within a
+// word are listed least-significant first, in the same order their bits are
+// passed to FromBinary (so TxType, where present, is the word's top byte),
+// and concatenating a word's FieldSpec widths always sums to 256 bits.
+type FieldSpec struct {
+	Word     int
+	Name     string
+	BitsSize int
+}
+
+func rawWord(word int, name string) FieldSpec {
+	return FieldSpec{Word: word, Name: name, BitsSize: 256}
+}
+
+func paddingField(word, bitsSize int) FieldSpec {
+	return FieldSpec{Word: word, Name: "Padding", BitsSize: bitsSize}
+}
+
+// PubDataLayout returns the machine-readable field layout of the pubData
+// produced by the CollectPubDataFromXxx function for txType, so that L1
+// contract ABIs can be generated from (and kept in sync with) the same
+// source of truth the circuit uses to pack pubData. The layout is a literal
+// restatement of the corresponding CollectPubDataFromXxx function: it does
+// not recompute anything, so a change to one must be mirrored in the other.
+func PubDataLayout(txType uint8) []FieldSpec {
+	switch txType {
+	case TxTypeRegisterZns:
+		return []FieldSpec{
+			paddingField(0, 216),
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "AccountName"),
+			rawWord(2, "AccountNameHash"),
+			rawWord(3, "PubKeyX"),
+			rawWord(4, "PubKeyY"),
+			paddingField(5, 256),
+		}
+	case TxTypeDeposit:
+		return []FieldSpec{
+			paddingField(0, 72),
+			{0, "AssetAmount", StateAmountBitsSize},
+			{0, "AssetId", AssetIdBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "AccountNameHash"),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeDepositNft:
+		return []FieldSpec{
+			paddingField(0, 16),
+			{0, "NftL1Address", AddressBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			{1, "CollectionId", CollectionIdBitsSize},
+			{1, "CreatorTreasuryRate", CreatorTreasuryRateBitsSize},
+			{1, "CreatorAccountIndex", AccountIndexBitsSize},
+			paddingField(1, 192),
+			rawWord(2, "NftContentHash"),
+			rawWord(3, "NftL1TokenId"),
+			rawWord(4, "AccountNameHash"),
+			paddingField(5, 256),
+		}
+	case TxTypeTransfer:
+		return []FieldSpec{
+			paddingField(0, 64),
+			{0, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{0, "GasFeeAssetId", AssetIdBitsSize},
+			{0, "GasAccountIndex", AccountIndexBitsSize},
+			{0, "AssetAmount", PackedAmountBitsSize},
+			{0, "AssetId", AssetIdBitsSize},
+			{0, "ToAccountIndex", AccountIndexBitsSize},
+			{0, "FromAccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "CallDataHash"),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeWithdraw:
+		return []FieldSpec{
+			paddingField(0, 40),
+			{0, "AssetId", AssetIdBitsSize},
+			{0, "ToAddress", AddressBitsSize},
+			{0, "FromAccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			{1, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{1, "GasFeeAssetId", AssetIdBitsSize},
+			{1, "GasAccountIndex", AccountIndexBitsSize},
+			{1, "AssetAmount", StateAmountBitsSize},
+			paddingField(1, 64),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeCreateCollection:
+		return []FieldSpec{
+			paddingField(0, 136),
+			{0, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{0, "GasFeeAssetId", AssetIdBitsSize},
+			{0, "GasAccountIndex", AccountIndexBitsSize},
+			{0, "CollectionId", CollectionIdBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			paddingField(1, 256), paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeMintNft:
+		return []FieldSpec{
+			paddingField(0, 48),
+			{0, "CollectionId", CollectionIdBitsSize},
+			{0, "CreatorTreasuryRate", CreatorTreasuryRateBitsSize},
+			{0, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{0, "GasFeeAssetId", AssetIdBitsSize},
+			{0, "GasAccountIndex", AccountIndexBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "ToAccountIndex", AccountIndexBitsSize},
+			{0, "CreatorAccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "NftContentHash"),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeTransferNft:
+		return []FieldSpec{
+			paddingField(0, 80),
+			{0, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{0, "GasFeeAssetId", AssetIdBitsSize},
+			{0, "GasAccountIndex", AccountIndexBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "ToAccountIndex", AccountIndexBitsSize},
+			{0, "FromAccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "CallDataHash"),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeAtomicMatch:
+		return []FieldSpec{
+			paddingField(0, 48),
+			{0, "AssetId", AssetIdBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "SellOfferId", OfferIdBitsSize},
+			{0, "SellerAccountIndex", AccountIndexBitsSize},
+			{0, "BuyOfferId", OfferIdBitsSize},
+			{0, "BuyerAccountIndex", AccountIndexBitsSize},
+			{0, "SubmitterAccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			{1, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{1, "GasFeeAssetId", AssetIdBitsSize},
+			{1, "GasAccountIndex", AccountIndexBitsSize},
+			{1, "TreasuryAmount", PackedAmountBitsSize},
+			{1, "CreatorAmount", PackedAmountBitsSize},
+			{1, "AssetAmount", PackedAmountBitsSize},
+			paddingField(1, 72),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeCancelOffer:
+		return []FieldSpec{
+			paddingField(0, 128),
+			{0, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{0, "GasFeeAssetId", AssetIdBitsSize},
+			{0, "GasAccountIndex", AccountIndexBitsSize},
+			{0, "OfferId", OfferIdBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			paddingField(1, 256), paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeWithdrawNft:
+		return []FieldSpec{
+			paddingField(0, 112),
+			{0, "CollectionId", CollectionIdBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "CreatorTreasuryRate", FeeRateBitsSize},
+			{0, "CreatorAccountIndex", AccountIndexBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "NftL1Address"),
+			{2, "GasFeeAssetAmount", PackedFeeBitsSize},
+			{2, "GasFeeAssetId", AssetIdBitsSize},
+			{2, "GasAccountIndex", AccountIndexBitsSize},
+			{2, "ToAddress", AddressBitsSize},
+			paddingField(2, 32),
+			rawWord(3, "NftContentHash"),
+			rawWord(4, "NftL1TokenId"),
+			rawWord(5, "CreatorAccountNameHash"),
+		}
+	case TxTypeFullExit:
+		return []FieldSpec{
+			paddingField(0, 72),
+			{0, "AssetAmount", StateAmountBitsSize},
+			{0, "AssetId", AssetIdBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "AccountNameHash"),
+			paddingField(2, 256), paddingField(3, 256), paddingField(4, 256), paddingField(5, 256),
+		}
+	case TxTypeFullExitNft:
+		return []FieldSpec{
+			paddingField(0, 112),
+			{0, "CollectionId", CollectionIdBitsSize},
+			{0, "NftIndex", NftIndexBitsSize},
+			{0, "CreatorTreasuryRate", FeeRateBitsSize},
+			{0, "CreatorAccountIndex", AccountIndexBitsSize},
+			{0, "AccountIndex", AccountIndexBitsSize},
+			{0, "TxType", TxTypeBitsSize},
+			rawWord(1, "NftL1Address"),
+			rawWord(2, "AccountNameHash"),
+			rawWord(3, "CreatorAccountNameHash"),
+			rawWord(4, "NftContentHash"),
+			rawWord(5, "NftL1TokenId"),
+		}
+	default:
+		return nil
+	}
+}
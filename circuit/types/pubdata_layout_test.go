@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPubDataLayoutMatchesPubDataSizePerTx checks that PubDataLayout's
+// per-word bit widths sum to exactly 256 bits, and that the highest word
+// index it describes never exceeds the pubData array CollectPubDataFromXxx
+// actually produces, for every tx type that has a layout.
+func TestPubDataLayoutMatchesPubDataSizePerTx(t *testing.T) {
+	txTypes := []uint8{
+		TxTypeRegisterZns, TxTypeDeposit, TxTypeDepositNft, TxTypeTransfer,
+		TxTypeWithdraw, TxTypeCreateCollection, TxTypeMintNft, TxTypeTransferNft,
+		TxTypeAtomicMatch, TxTypeCancelOffer, TxTypeWithdrawNft, TxTypeFullExit,
+		TxTypeFullExitNft,
+	}
+	for _, txType := range txTypes {
+		layout := PubDataLayout(txType)
+		require.NotEmptyf(t, layout, "tx type %d should have a layout", txType)
+
+		wordBits := make(map[int]int)
+		maxWord := 0
+		for _, field := range layout {
+			wordBits[field.Word] += field.BitsSize
+			if field.Word > maxWord {
+				maxWord = field.Word
+			}
+		}
+		require.Lessf(t, maxWord, PubDataSizePerTx, "tx type %d layout references word %d but pubData only has %d words", txType, maxWord, PubDataSizePerTx)
+		for word, bits := range wordBits {
+			require.Equalf(t, 256, bits, "tx type %d word %d should be fully specified (256 bits), got %d", txType, word, bits)
+		}
+	}
+
+	require.Nil(t, PubDataLayout(0xFF))
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "math/big"
+
+// SplitSalePriceNative splits a sale price into the creator's treasury share
+// at rate (out of RateBase) and the remainder owed to the seller. price*rate
+// may not be evenly divisible by RateBase, so this rounds down: toCreator is
+// floor(price*rate/RateBase), and any fractional unit stays with the seller.
+// This keeps the split exact over the integers (toCreator+toSeller always
+// sums back to price) and matches the rounding direction enforced in-circuit
+// by the SplitSalePrice helper.
+func SplitSalePriceNative(price *big.Int, rate int64) (toCreator, toSeller *big.Int) {
+	toCreator = new(big.Int).Mul(price, big.NewInt(rate))
+	toCreator.Div(toCreator, big.NewInt(RateBase))
+	toSeller = new(big.Int).Sub(price, toCreator)
+	return toCreator, toSeller
+}
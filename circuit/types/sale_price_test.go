@@ -0,0 +1,40 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSalePriceNativeExact(t *testing.T) {
+	// 1000 * 2000 / 10000 = 200 exactly.
+	toCreator, toSeller := SplitSalePriceNative(big.NewInt(1000), 2000)
+	require.Equal(t, big.NewInt(200), toCreator)
+	require.Equal(t, big.NewInt(800), toSeller)
+}
+
+func TestSplitSalePriceNativeRoundsDown(t *testing.T) {
+	// 7 * 2000 / 10000 = 1.4, floors to 1; the remaining 0.4 stays with the seller.
+	toCreator, toSeller := SplitSalePriceNative(big.NewInt(7), 2000)
+	require.Equal(t, big.NewInt(1), toCreator)
+	require.Equal(t, big.NewInt(6), toSeller)
+	require.Equal(t, big.NewInt(7), new(big.Int).Add(toCreator, toSeller))
+}
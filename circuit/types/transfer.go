@@ -17,6 +17,12 @@
 
 package types
 
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
 type TransferTx struct {
 	FromAccountIndex  int64
 	ToAccountIndex    int64
@@ -27,6 +33,9 @@ type TransferTx struct {
 	GasFeeAssetId     int64
 	GasFeeAssetAmount int64
 	CallDataHash      []byte
+	// ToL1Address is the signed, optional L1 bridging destination for this
+	// transfer. Zero means this is an ordinary L2 transfer to ToAccountIndex.
+	ToL1Address *big.Int
 }
 
 type TransferTxConstraints struct {
@@ -39,6 +48,7 @@ type TransferTxConstraints struct {
 	GasFeeAssetId     Variable
 	GasFeeAssetAmount Variable
 	CallDataHash      Variable
+	ToL1Address       Variable
 }
 
 func EmptyTransferTxWitness() (witness TransferTxConstraints) {
@@ -52,10 +62,15 @@ func EmptyTransferTxWitness() (witness TransferTxConstraints) {
 		GasFeeAssetId:     ZeroInt,
 		GasFeeAssetAmount: ZeroInt,
 		CallDataHash:      ZeroInt,
+		ToL1Address:       ZeroInt,
 	}
 }
 
 func SetTransferTxWitness(tx *TransferTx) (witness TransferTxConstraints) {
+	toL1Address := Variable(ZeroInt)
+	if tx.ToL1Address != nil {
+		toL1Address = tx.ToL1Address
+	}
 	witness = TransferTxConstraints{
 		FromAccountIndex:  tx.FromAccountIndex,
 		ToAccountIndex:    tx.ToAccountIndex,
@@ -66,6 +81,7 @@ func SetTransferTxWitness(tx *TransferTx) (witness TransferTxConstraints) {
 		GasFeeAssetId:     tx.GasFeeAssetId,
 		GasFeeAssetAmount: tx.GasFeeAssetAmount,
 		CallDataHash:      tx.CallDataHash,
+		ToL1Address:       toL1Address,
 	}
 	return witness
 }
@@ -78,11 +94,32 @@ func ComputeHashFromTransferTx(api API, tx TransferTxConstraints, nonce Variable
 		PackInt64Variables(api, tx.ToAccountIndex, tx.AssetId, tx.AssetAmount),
 		tx.ToAccountNameHash,
 		tx.CallDataHash,
+		tx.ToL1Address,
 	)
 	hashVal = hFunc.Sum()
 	return hashVal
 }
 
+// ComputeTransferMsgHash is the native twin of ComputeHashFromTransferTx: it
+// hashes the same fields in the same order using math/big arithmetic instead
+// of circuit Variables, so the result can be compared against a
+// proving-circuit execution for parity.
+func ComputeTransferMsgHash(tx *TransferTx, nonce int64, expiredAt int64) (hashVal []byte) {
+	toL1Address := big.NewInt(0)
+	if tx.ToL1Address != nil {
+		toL1Address = tx.ToL1Address
+	}
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(packInt64VariablesNative(ChainId, tx.FromAccountIndex, nonce, expiredAt).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.GasAccountIndex, tx.GasFeeAssetId, tx.GasFeeAssetAmount).FillBytes(make([]byte, 32)))
+	hFunc.Write(packInt64VariablesNative(tx.ToAccountIndex, tx.AssetId, tx.AssetAmount).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(tx.ToAccountNameHash).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).SetBytes(tx.CallDataHash).FillBytes(make([]byte, 32)))
+	hFunc.Write(new(big.Int).Set(toL1Address).FillBytes(make([]byte, 32)))
+	hashVal = hFunc.Sum(nil)
+	return hashVal
+}
+
 func VerifyTransferTx(
 	api API, flag Variable,
 	tx *TransferTxConstraints,
@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type transferHashConstraints struct {
+	Tx         TransferTxConstraints
+	Nonce      Variable
+	ExpiredAt  Variable
+	ExpectHash Variable
+}
+
+func (circuit transferHashConstraints) Define(api API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	hashVal := ComputeHashFromTransferTx(api, circuit.Tx, circuit.Nonce, circuit.ExpiredAt, hFunc)
+	api.AssertIsEqual(hashVal, circuit.ExpectHash)
+	return nil
+}
+
+// assertTransferHashParity checks that the native ComputeTransferMsgHash and the in-circuit
+// ComputeHashFromTransferTx agree bit-for-bit on the same inputs.
+func assertTransferHashParity(t *testing.T, tx *TransferTx, nonce, expiredAt int64) {
+	nativeHash := ComputeTransferMsgHash(tx, nonce, expiredAt)
+
+	assert := test.NewAssert(t)
+	var circuit, witness transferHashConstraints
+	witness.Tx = SetTransferTxWitness(tx)
+	witness.Nonce = nonce
+	witness.ExpiredAt = expiredAt
+	witness.ExpectHash = new(big.Int).SetBytes(nativeHash)
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+// TestAssertTransferHashParityL2Index checks an ordinary L2-indexed transfer,
+// which leaves ToL1Address unset (nil, i.e. zero).
+func TestAssertTransferHashParityL2Index(t *testing.T) {
+	tx := &TransferTx{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		ToAccountNameHash: []byte{0x01, 0x02, 0x03},
+		AssetId:           3,
+		AssetAmount:       100,
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: 10,
+		CallDataHash:      []byte{0x04, 0x05, 0x06},
+	}
+	assertTransferHashParity(t, tx, 7, 1000)
+}
+
+// TestAssertTransferHashParityL1Address checks a transfer-to-L1-address,
+// confirming ToL1Address is bound into the signed hash and that setting it
+// changes the hash relative to the same transfer with ToL1Address unset.
+func TestAssertTransferHashParityL1Address(t *testing.T) {
+	tx := &TransferTx{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		ToAccountNameHash: []byte{0x01, 0x02, 0x03},
+		AssetId:           3,
+		AssetAmount:       100,
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: 10,
+		CallDataHash:      []byte{0x04, 0x05, 0x06},
+		ToL1Address:       new(big.Int).SetBytes([]byte{0x11, 0x22, 0x33, 0x44}),
+	}
+	assertTransferHashParity(t, tx, 7, 1000)
+
+	withoutL1Address := *tx
+	withoutL1Address.ToL1Address = nil
+	if new(big.Int).SetBytes(ComputeTransferMsgHash(tx, 7, 1000)).Cmp(
+		new(big.Int).SetBytes(ComputeTransferMsgHash(&withoutL1Address, 7, 1000))) == 0 {
+		t.Fatal("ToL1Address should change the signed hash")
+	}
+}
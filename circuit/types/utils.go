@@ -18,11 +18,24 @@
 package types
 
 import (
+	"fmt"
+
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
 	"github.com/consensys/gnark/std/algebra/twistededwards"
 	eddsaConstraints "github.com/consensys/gnark/std/signature/eddsa"
 )
 
+// AssertNonNegativeInt64 rejects a negative int64 before it reaches a
+// Variable assignment. Assigning a negative int64 to a Variable silently wraps
+// it into the scalar field rather than rejecting it, so any index/id/
+// amount field sourced from outside this package must be checked first.
+func AssertNonNegativeInt64(name string, v int64) error {
+	if v < 0 {
+		return fmt.Errorf("%s should not be negative: %d", name, v)
+	}
+	return nil
+}
+
 func SetPubKeyWitness(pk *eddsa.PublicKey) (witness eddsaConstraints.PublicKey) {
 	witness.A.X = pk.A.X
 	witness.A.Y = pk.A.Y
@@ -48,3 +61,90 @@ func Min(api API, a, b Variable) Variable {
 	minAB := api.Select(api.IsZero(api.Add(1, api.Cmp(a, b))), a, b)
 	return minAB
 }
+
+// AssertReserveAboveMinimum constrains that a pool reserve of reserveBefore,
+// after a withdrawal of delta, does not fall below minReserve. It is the
+// in-circuit counterpart of the native bound checked by
+// wasm/txtypes.ValidateRemoveLiquidity.
+func AssertReserveAboveMinimum(api API, reserveBefore, delta, minReserve Variable) {
+	remaining := api.Sub(reserveBefore, delta)
+	api.AssertIsLessOrEqual(minReserve, remaining)
+}
+
+// AssertSwapOutputWithinReserve constrains that a swap's output amount does
+// not reach the pool's available reserve of the output asset, the in-circuit
+// counterpart of the native bound checked by
+// wasm/txtypes.ValidateSwapAmounts.
+func AssertSwapOutputWithinReserve(api API, amountOut, reserveOut Variable) {
+	api.AssertIsLessOrEqual(api.Add(amountOut, 1), reserveOut)
+}
+
+// AssertMatchingPoolAssetIds constrains that a swap or liquidity tx's
+// declared asset pair equals the pool's own asset pair, the in-circuit
+// counterpart of the native check performed by
+// wasm/txtypes.ValidateMatchingPoolAssetIds.
+func AssertMatchingPoolAssetIds(api API, txAssetAId, txAssetBId, poolAssetAId, poolAssetBId Variable) {
+	api.AssertIsEqual(txAssetAId, poolAssetAId)
+	api.AssertIsEqual(txAssetBId, poolAssetBId)
+}
+
+// AssertAtomicMatchConservation constrains that an atomic match's creator
+// royalty, matcher (treasury) fee, and same-asset gas credit do not together
+// exceed the buyer's payment, the in-circuit counterpart of the native bound
+// checked by wasm/txtypes.ValidateAtomicMatchConservation. gasCredit should
+// be the submitter's gas fee when it is paid in the sale asset, or 0
+// otherwise.
+func AssertAtomicMatchConservation(api API, buyAmount, creatorAmount, treasuryAmount, gasCredit Variable) {
+	takenFromPayment := api.Add(creatorAmount, treasuryAmount, gasCredit)
+	api.AssertIsLessOrEqual(takenFromPayment, buyAmount)
+}
+
+// AssertNameHashConsistency constrains that a tx's own copy of an account
+// name hash equals the name hash actually committed on that account's leaf,
+// the in-circuit counterpart of the native check performed by
+// wasm/txtypes.ValidateNameHashConsistency. isEnabled follows the same
+// gating convention as IsVariableEqual: pass 0 for a tx slot the block isn't
+// actually using so the constraint collapses to 0 == 0 instead of firing.
+func AssertNameHashConsistency(api API, isEnabled, txNameHash, accountNameHash Variable) {
+	IsVariableEqual(api, isEnabled, txNameHash, accountNameHash)
+}
+
+// AssertSufficientBalanceAfterGasFee constrains that assetAmount still fits
+// within balance once gasFeeAssetAmount has already been deducted from it,
+// the in-circuit counterpart of the native order-of-operations checked by
+// wasm/txtypes.ValidateSufficientBalanceAfterGasFee. Callers whose gas fee is
+// paid out of a different asset than assetAmount should pass that asset's
+// own balance as balance and 0 as gasFeeAssetAmount, since there is then
+// nothing to deduct before the check.
+func AssertSufficientBalanceAfterGasFee(api API, isEnabled, assetAmount, gasFeeAssetAmount, balance Variable) {
+	IsVariableLessOrEqual(api, isEnabled, assetAmount, api.Sub(balance, gasFeeAssetAmount))
+}
+
+// AssertRecipientExists constrains that recipientAccountNameHash, a
+// transfer recipient's witnessed account name hash, is not the zero value
+// EmptyAccount leaves an unregistered slot with, the in-circuit counterpart
+// of the native check performed by circuit.ValidateRecipientExists. There is
+// no auto-register branch: a transfer whose recipient slot is still empty
+// must be rejected and resubmitted after a RegisterZnsTx, the same way
+// circuit.ValidateRecipientExists documents for its native callers.
+func AssertRecipientExists(api API, isEnabled, recipientAccountNameHash Variable) {
+	IsVariableDifferent(api, isEnabled, recipientAccountNameHash, 0)
+}
+
+// AssertUnlockWithinLocked constrains that unlockAmount does not exceed
+// lockedAmount, the in-circuit counterpart of the native bound checked by
+// util.ValidateUnlockAmount. This repo has no lock/unlock tx type to wire
+// this into (see that function's doc comment); it is provided standalone
+// for the same reason.
+func AssertUnlockWithinLocked(api API, isEnabled, unlockAmount, lockedAmount Variable) {
+	IsVariableLessOrEqual(api, isEnabled, unlockAmount, lockedAmount)
+}
+
+// AssertDistinctTreasuryAndPoolAccounts constrains that a swap's treasury
+// account and pool account are not the same account index, the in-circuit
+// counterpart of the native check performed by
+// txtypes.ValidateDistinctTreasuryAndPoolAccounts. See that function's doc
+// comment for why this package has no swap tx witness to wire it into yet.
+func AssertDistinctTreasuryAndPoolAccounts(api API, isEnabled, treasuryAccountIndex, poolAccountIndex Variable) {
+	IsVariableDifferent(api, isEnabled, treasuryAccountIndex, poolAccountIndex)
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertNonNegativeInt64(t *testing.T) {
+	require.NoError(t, AssertNonNegativeInt64("AccountIndex", 0))
+	require.NoError(t, AssertNonNegativeInt64("AccountIndex", 42))
+	require.Error(t, AssertNonNegativeInt64("AccountIndex", -1))
+}
+
+type reserveAboveMinimumConstraints struct {
+	ReserveBefore Variable
+	Delta         Variable
+	MinReserve    Variable
+}
+
+func (circuit reserveAboveMinimumConstraints) Define(api API) error {
+	AssertReserveAboveMinimum(api, circuit.ReserveBefore, circuit.Delta, circuit.MinReserve)
+	return nil
+}
+
+func TestAssertReserveAboveMinimum(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit reserveAboveMinimumConstraints
+
+	witness := reserveAboveMinimumConstraints{ReserveBefore: 1000000, Delta: 100000, MinReserve: 500000}
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	failingWitness := reserveAboveMinimumConstraints{ReserveBefore: 1000000, Delta: 900000, MinReserve: 500000}
+	assert.SolvingFailed(&circuit, &failingWitness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type swapOutputWithinReserveConstraints struct {
+	AmountOut  Variable
+	ReserveOut Variable
+}
+
+func (circuit swapOutputWithinReserveConstraints) Define(api API) error {
+	AssertSwapOutputWithinReserve(api, circuit.AmountOut, circuit.ReserveOut)
+	return nil
+}
+
+func TestAssertSwapOutputWithinReserve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit swapOutputWithinReserveConstraints
+
+	witness := swapOutputWithinReserveConstraints{AmountOut: 9000, ReserveOut: 2000000}
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	equalWitness := swapOutputWithinReserveConstraints{AmountOut: 2000000, ReserveOut: 2000000}
+	assert.SolvingFailed(&circuit, &equalWitness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	exceedingWitness := swapOutputWithinReserveConstraints{AmountOut: 3000000, ReserveOut: 2000000}
+	assert.SolvingFailed(&circuit, &exceedingWitness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type atomicMatchConservationConstraints struct {
+	BuyAmount      Variable
+	CreatorAmount  Variable
+	TreasuryAmount Variable
+	GasCredit      Variable
+}
+
+func (circuit atomicMatchConservationConstraints) Define(api API) error {
+	AssertAtomicMatchConservation(api, circuit.BuyAmount, circuit.CreatorAmount, circuit.TreasuryAmount, circuit.GasCredit)
+	return nil
+}
+
+func TestAssertAtomicMatchConservation(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit atomicMatchConservationConstraints
+
+	witness := atomicMatchConservationConstraints{BuyAmount: 100, CreatorAmount: 5, TreasuryAmount: 3, GasCredit: 2}
+	assert.SolvingSucceeded(&circuit, &witness, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	imbalanced := atomicMatchConservationConstraints{BuyAmount: 100, CreatorAmount: 60, TreasuryAmount: 60, GasCredit: 0}
+	assert.SolvingFailed(&circuit, &imbalanced, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type nameHashConsistencyConstraints struct {
+	IsEnabled       Variable
+	TxNameHash      Variable
+	AccountNameHash Variable
+}
+
+func (circuit nameHashConsistencyConstraints) Define(api API) error {
+	AssertNameHashConsistency(api, circuit.IsEnabled, circuit.TxNameHash, circuit.AccountNameHash)
+	return nil
+}
+
+func TestAssertNameHashConsistency(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit nameHashConsistencyConstraints
+
+	matching := nameHashConsistencyConstraints{IsEnabled: 1, TxNameHash: 42, AccountNameHash: 42}
+	assert.SolvingSucceeded(&circuit, &matching, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	mismatched := nameHashConsistencyConstraints{IsEnabled: 1, TxNameHash: 42, AccountNameHash: 7}
+	assert.SolvingFailed(&circuit, &mismatched, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	// disabled: a mismatch is not constrained
+	disabledMismatch := nameHashConsistencyConstraints{IsEnabled: 0, TxNameHash: 42, AccountNameHash: 7}
+	assert.SolvingSucceeded(&circuit, &disabledMismatch, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type sufficientBalanceAfterGasFeeConstraints struct {
+	IsEnabled         Variable
+	AssetAmount       Variable
+	GasFeeAssetAmount Variable
+	Balance           Variable
+}
+
+func (circuit sufficientBalanceAfterGasFeeConstraints) Define(api API) error {
+	AssertSufficientBalanceAfterGasFee(api, circuit.IsEnabled, circuit.AssetAmount, circuit.GasFeeAssetAmount, circuit.Balance)
+	return nil
+}
+
+// TestAssertSufficientBalanceAfterGasFee covers the same ordering gap
+// wasm/txtypes.TestValidateSufficientBalanceAfterGasFeeOrderingMatters does:
+// an amount that fits the raw balance but not once the gas fee is deducted
+// first must fail.
+func TestAssertSufficientBalanceAfterGasFee(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit sufficientBalanceAfterGasFeeConstraints
+
+	overdraws := sufficientBalanceAfterGasFeeConstraints{IsEnabled: 1, AssetAmount: 90, GasFeeAssetAmount: 30, Balance: 100}
+	assert.SolvingFailed(&circuit, &overdraws, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	fitsAfterFee := sufficientBalanceAfterGasFeeConstraints{IsEnabled: 1, AssetAmount: 70, GasFeeAssetAmount: 30, Balance: 100}
+	assert.SolvingSucceeded(&circuit, &fitsAfterFee, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	// disabled: an overdraw is not constrained
+	disabledOverdraws := sufficientBalanceAfterGasFeeConstraints{IsEnabled: 0, AssetAmount: 90, GasFeeAssetAmount: 30, Balance: 100}
+	assert.SolvingSucceeded(&circuit, &disabledOverdraws, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type recipientExistsConstraints struct {
+	IsEnabled                Variable
+	RecipientAccountNameHash Variable
+}
+
+func (circuit recipientExistsConstraints) Define(api API) error {
+	AssertRecipientExists(api, circuit.IsEnabled, circuit.RecipientAccountNameHash)
+	return nil
+}
+
+// TestAssertRecipientExists covers the same cases
+// circuit.TestValidateRecipientExists does natively: a registered recipient
+// (non-zero account name hash) passes, an unregistered one (the zero value
+// EmptyAccount leaves it at) fails.
+func TestAssertRecipientExists(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit recipientExistsConstraints
+
+	registered := recipientExistsConstraints{IsEnabled: 1, RecipientAccountNameHash: 42}
+	assert.SolvingSucceeded(&circuit, &registered, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	notRegistered := recipientExistsConstraints{IsEnabled: 1, RecipientAccountNameHash: 0}
+	assert.SolvingFailed(&circuit, &notRegistered, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	// disabled: an empty recipient is not constrained
+	disabledNotRegistered := recipientExistsConstraints{IsEnabled: 0, RecipientAccountNameHash: 0}
+	assert.SolvingSucceeded(&circuit, &disabledNotRegistered, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type unlockWithinLockedConstraints struct {
+	IsEnabled    Variable
+	UnlockAmount Variable
+	LockedAmount Variable
+}
+
+func (circuit unlockWithinLockedConstraints) Define(api API) error {
+	AssertUnlockWithinLocked(api, circuit.IsEnabled, circuit.UnlockAmount, circuit.LockedAmount)
+	return nil
+}
+
+// TestAssertUnlockWithinLocked covers the same over-unlock case
+// util.TestValidateUnlockAmountRejectsOverUnlock does natively.
+func TestAssertUnlockWithinLocked(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit unlockWithinLockedConstraints
+
+	overUnlock := unlockWithinLockedConstraints{IsEnabled: 1, UnlockAmount: 101, LockedAmount: 100}
+	assert.SolvingFailed(&circuit, &overUnlock, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	withinLocked := unlockWithinLockedConstraints{IsEnabled: 1, UnlockAmount: 100, LockedAmount: 100}
+	assert.SolvingSucceeded(&circuit, &withinLocked, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	// disabled: an over-unlock is not constrained
+	disabledOverUnlock := unlockWithinLockedConstraints{IsEnabled: 0, UnlockAmount: 101, LockedAmount: 100}
+	assert.SolvingSucceeded(&circuit, &disabledOverUnlock, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type distinctTreasuryAndPoolAccountsConstraints struct {
+	IsEnabled            Variable
+	TreasuryAccountIndex Variable
+	PoolAccountIndex     Variable
+}
+
+func (circuit distinctTreasuryAndPoolAccountsConstraints) Define(api API) error {
+	AssertDistinctTreasuryAndPoolAccounts(api, circuit.IsEnabled, circuit.TreasuryAccountIndex, circuit.PoolAccountIndex)
+	return nil
+}
+
+// TestAssertDistinctTreasuryAndPoolAccounts covers the same coincident-
+// account case txtypes.TestValidateDistinctTreasuryAndPoolAccountsRejectsCoincidence
+// does natively.
+func TestAssertDistinctTreasuryAndPoolAccounts(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit distinctTreasuryAndPoolAccountsConstraints
+
+	coincident := distinctTreasuryAndPoolAccountsConstraints{IsEnabled: 1, TreasuryAccountIndex: 7, PoolAccountIndex: 7}
+	assert.SolvingFailed(&circuit, &coincident, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	distinct := distinctTreasuryAndPoolAccountsConstraints{IsEnabled: 1, TreasuryAccountIndex: 7, PoolAccountIndex: 8}
+	assert.SolvingSucceeded(&circuit, &distinct, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	// disabled: a coincident account is not constrained
+	disabledCoincident := distinctTreasuryAndPoolAccountsConstraints{IsEnabled: 0, TreasuryAccountIndex: 7, PoolAccountIndex: 7}
+	assert.SolvingSucceeded(&circuit, &disabledCoincident, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
+
+type matchingPoolAssetIdsConstraints struct {
+	TxAssetAId   Variable
+	TxAssetBId   Variable
+	PoolAssetAId Variable
+	PoolAssetBId Variable
+}
+
+func (circuit matchingPoolAssetIdsConstraints) Define(api API) error {
+	AssertMatchingPoolAssetIds(api, circuit.TxAssetAId, circuit.TxAssetBId, circuit.PoolAssetAId, circuit.PoolAssetBId)
+	return nil
+}
+
+// TestAssertMatchingPoolAssetIds covers the same mismatched-pair case
+// txtypes.TestValidateMatchingPoolAssetIdsRejectsMismatch does natively.
+func TestAssertMatchingPoolAssetIds(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit matchingPoolAssetIdsConstraints
+
+	matching := matchingPoolAssetIdsConstraints{TxAssetAId: 1, TxAssetBId: 2, PoolAssetAId: 1, PoolAssetBId: 2}
+	assert.SolvingSucceeded(&circuit, &matching, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	mismatchedA := matchingPoolAssetIdsConstraints{TxAssetAId: 4, TxAssetBId: 2, PoolAssetAId: 1, PoolAssetBId: 2}
+	assert.SolvingFailed(&circuit, &mismatchedA, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+
+	mismatchedB := matchingPoolAssetIdsConstraints{TxAssetAId: 1, TxAssetBId: 3, PoolAssetAId: 1, PoolAssetBId: 2}
+	assert.SolvingFailed(&circuit, &mismatchedB, test.WithBackends(backend.GROTH16),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
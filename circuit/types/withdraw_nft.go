@@ -117,7 +117,7 @@ func VerifyWithdrawNftTx(
 	IsVariableEqual(api, flag, tx.AccountIndex, accountsBefore[fromAccount].AccountIndex)
 	IsVariableEqual(api, flag, tx.CreatorAccountIndex, accountsBefore[creatorAccount].AccountIndex)
 	// account name hash
-	IsVariableEqual(api, flag, tx.CreatorAccountNameHash, accountsBefore[creatorAccount].AccountNameHash)
+	AssertNameHashConsistency(api, flag, tx.CreatorAccountNameHash, accountsBefore[creatorAccount].AccountNameHash)
 	// collection id
 	IsVariableEqual(api, flag, tx.CollectionId, nftBefore.CollectionId)
 	// asset id
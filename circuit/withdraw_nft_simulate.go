@@ -0,0 +1,60 @@
+package circuit
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// WithdrawNftState carries the Merkle proof material SimulateWithdrawNftRoots
+// needs to recompute the roots a withdraw-nft tx leaves behind. It deliberately
+// stops short of a full in-memory Merkle tree implementation, which belongs to
+// the node software built on top of this library rather than this circuit
+// package: NewOwnerAssetRoot is taken as an input (already recomputed by the
+// caller's asset tree after debiting the gas fee) rather than rederived here.
+type WithdrawNftState struct {
+	// Owner is AccountsInfoBefore[0], the nft owner paying the gas fee.
+	Owner *types.Account
+	// NewOwnerAssetRoot is Owner's asset sub-tree root after debiting
+	// GasFeeAssetAmount from GasFeeAssetId.
+	NewOwnerAssetRoot []byte
+	// OwnerMerkleProof is Owner's AccountMerkleLevels-deep Merkle proof,
+	// bottom-up, the same order tx.MerkleProofsAccountBefore[0] is built in.
+	OwnerMerkleProof [][]byte
+	// NftMerkleProof is the withdrawn nft's NftMerkleLevels-deep Merkle
+	// proof, bottom-up, the same order tx.MerkleProofsNftBefore is built in.
+	NftMerkleProof [][]byte
+}
+
+// SimulateWithdrawNftRoots computes the nft and account roots a withdraw-nft
+// tx would produce: the nft leaf is cleared to the nil (all-zero) node, the
+// same way GetAssetDeltasAndNftDeltaFromWithdrawNft clears it in-circuit, and
+// the owner's account leaf is rehashed with its new asset sub-tree root.
+func SimulateWithdrawNftRoots(txInfo *types.WithdrawNftTx, state *WithdrawNftState) (newNftRoot, newAccountRoot []byte, err error) {
+	if txInfo == nil {
+		return nil, nil, errors.New("txInfo should not be nil")
+	}
+	if state == nil {
+		return nil, nil, errors.New("state should not be nil")
+	}
+	if state.Owner == nil {
+		return nil, nil, errors.New("state.Owner should not be nil")
+	}
+	if len(state.NftMerkleProof) != NftMerkleLevels {
+		return nil, nil, errors.New("state.NftMerkleProof has the wrong depth")
+	}
+	if len(state.OwnerMerkleProof) != AccountMerkleLevels {
+		return nil, nil, errors.New("state.OwnerMerkleProof has the wrong depth")
+	}
+
+	clearedNft := types.EmptyNft(txInfo.NftIndex)
+	nftLeafHash := types.NftNodeHash(clearedNft)
+	nftHelper := types.MerkleHelperFromIndex(txInfo.NftIndex, NftMerkleLevels)
+	newNftRoot = types.UpdateMerkleProofNative(nftLeafHash, state.NftMerkleProof, nftHelper)
+
+	accountLeafHash := types.AccountNodeHashNative(state.Owner, state.NewOwnerAssetRoot)
+	accountHelper := types.MerkleHelperFromIndex(state.Owner.AccountIndex, AccountMerkleLevels)
+	newAccountRoot = types.UpdateMerkleProofNative(accountLeafHash, state.OwnerMerkleProof, accountHelper)
+
+	return newNftRoot, newAccountRoot, nil
+}
@@ -0,0 +1,69 @@
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// zeroSubtreeRoots returns the root of an all-zero Merkle tree of the given
+// depth at every height, root[0] being the leaf hash itself and root[depth]
+// the tree root. A proof built entirely from these values is valid for any
+// index into a tree whose every leaf is leaf.
+func zeroSubtreeRoots(leaf []byte, depth int) [][]byte {
+	roots := make([][]byte, depth+1)
+	roots[0] = leaf
+	for i := 1; i <= depth; i++ {
+		roots[i] = types.UpdateMerkleProofNative(roots[i-1], [][]byte{roots[i-1]}, []int{0})
+	}
+	return roots
+}
+
+// TestSimulateWithdrawNftRootsClearsNftToNilHash checks that withdrawing an
+// nft out of an (otherwise all-empty) nft tree produces the same root as a
+// tree whose every leaf is the nil nft hash, i.e. that the withdrawn slot
+// becomes the nil hash rather than retaining its prior content.
+func TestSimulateWithdrawNftRootsClearsNftToNilHash(t *testing.T) {
+	nilNftHash := types.NftNodeHash(types.EmptyNft(0))
+	nftZeroRoots := zeroSubtreeRoots(nilNftHash, NftMerkleLevels)
+	nftProof := make([][]byte, NftMerkleLevels)
+	for i := range nftProof {
+		nftProof[i] = nftZeroRoots[i]
+	}
+
+	owner := types.EmptyAccount(1, types.EmptyAssetRoot.FillBytes(make([]byte, 32)))
+	ownerLeafHash := types.AccountNodeHashNative(owner, owner.AssetRoot)
+	accountZeroRoots := zeroSubtreeRoots(ownerLeafHash, AccountMerkleLevels)
+	accountProof := make([][]byte, AccountMerkleLevels)
+	for i := range accountProof {
+		accountProof[i] = accountZeroRoots[i]
+	}
+
+	txInfo := &types.WithdrawNftTx{
+		AccountIndex: owner.AccountIndex,
+		NftIndex:     7,
+	}
+	state := &WithdrawNftState{
+		Owner:             owner,
+		NewOwnerAssetRoot: owner.AssetRoot,
+		OwnerMerkleProof:  accountProof,
+		NftMerkleProof:    nftProof,
+	}
+
+	newNftRoot, newAccountRoot, err := SimulateWithdrawNftRoots(txInfo, state)
+	require.NoError(t, err)
+	require.Equal(t, nftZeroRoots[NftMerkleLevels], newNftRoot)
+	require.Equal(t, accountZeroRoots[AccountMerkleLevels], newAccountRoot)
+
+	// A nil-hash proof with the wrong depth is rejected rather than silently
+	// producing a bogus root.
+	_, _, err = SimulateWithdrawNftRoots(txInfo, &WithdrawNftState{
+		Owner:             owner,
+		NewOwnerAssetRoot: owner.AssetRoot,
+		OwnerMerkleProof:  accountProof,
+		NftMerkleProof:    nftProof[:NftMerkleLevels-1],
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WitnessGenerator runs SetTxWitness over a batch of *Tx across a bounded
+// pool of workers, so sequencer-side witness generation is not limited to
+// one core the way a plain loop over SetTxWitness is. Concurrency bounds
+// how many txs are being converted to witnesses at once, which in turn
+// bounds how many TxConstraints (each carrying its own Merkle proofs) are
+// live in memory at the same time.
+type WitnessGenerator struct {
+	concurrency int
+}
+
+// NewWitnessGenerator returns a WitnessGenerator that runs at most
+// concurrency SetTxWitness calls at a time. concurrency <= 0 is treated as
+// 1 (no concurrency), rather than an error, since a caller passing a
+// non-positive value almost always means "run serially."
+func NewWitnessGenerator(concurrency int) *WitnessGenerator {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &WitnessGenerator{concurrency: concurrency}
+}
+
+// GenerateTxWitnesses runs SetTxWitness over txs across the generator's
+// worker pool and returns one TxConstraints and one error per tx, in the
+// same order as txs (witnesses[i]/errs[i] is always txs[i]'s result),
+// regardless of which worker finished it or in what order. errs mirrors
+// the one-error-per-item aggregation VerifyIndependentProofs already uses
+// for proof verification: a caller can tell exactly which txs in the batch
+// failed instead of only learning the batch as a whole had a failure.
+func (g *WitnessGenerator) GenerateTxWitnesses(txs []*Tx) ([]TxConstraints, []error) {
+	witnesses := make([]TxConstraints, len(txs))
+	errs := make([]error, len(txs))
+	if len(txs) == 0 {
+		return witnesses, errs
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	concurrency := g.concurrency
+	if concurrency > len(txs) {
+		concurrency = len(txs)
+	}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if txs[i] == nil {
+					errs[i] = fmt.Errorf("txs[%d] should not be nil", i)
+					continue
+				}
+				witness, err := SetTxWitness(txs[i])
+				witnesses[i] = witness
+				errs[i] = err
+			}
+		}()
+	}
+	for i := range txs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return witnesses, errs
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWitnessGeneratorGenerateTxWitnessesMatchesSerial(t *testing.T) {
+	var txs []*Tx
+	for i := 0; i < 10; i++ {
+		tx := zeroTx()
+		tx.Nonce = int64(i)
+		txs = append(txs, tx)
+	}
+
+	generator := NewWitnessGenerator(4)
+	witnesses, errs := generator.GenerateTxWitnesses(txs)
+	require.Len(t, witnesses, len(txs))
+	require.Len(t, errs, len(txs))
+
+	for i, tx := range txs {
+		require.NoError(t, errs[i])
+		serialWitness, err := SetTxWitness(tx)
+		require.NoError(t, err)
+		require.Equal(t, serialWitness, witnesses[i])
+	}
+}
+
+func TestWitnessGeneratorGenerateTxWitnessesAggregatesErrorsByIndex(t *testing.T) {
+	validTx := zeroTx()
+	invalidTx := zeroTx()
+	invalidTx.Nonce = -1
+
+	generator := NewWitnessGenerator(2)
+	_, errs := generator.GenerateTxWitnesses([]*Tx{validTx, invalidTx, nil})
+
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.Error(t, errs[2])
+}
+
+func TestWitnessGeneratorNonPositiveConcurrencyRunsSerially(t *testing.T) {
+	generator := NewWitnessGenerator(0)
+	require.Equal(t, 1, generator.concurrency)
+	txs := []*Tx{zeroTx(), zeroTx()}
+
+	witnesses, errs := generator.GenerateTxWitnesses(txs)
+	require.Len(t, witnesses, 2)
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+}
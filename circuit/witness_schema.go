@@ -0,0 +1,48 @@
+package circuit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// WitnessFieldSchema describes one field of TxConstraints (or a struct
+// nested within it): its declared Go name, its scalar type once any array
+// dimensions are stripped off, the sizes of those array dimensions in
+// outer-to-inner order, and, for struct-typed fields, the schema of each of
+// its own fields.
+type WitnessFieldSchema struct {
+	Name      string               `json:"name"`
+	Type      string               `json:"type"`
+	ArrayDims []int                `json:"array_dims,omitempty"`
+	Fields    []WitnessFieldSchema `json:"fields,omitempty"`
+}
+
+func buildWitnessFieldSchema(name string, t reflect.Type) WitnessFieldSchema {
+	var dims []int
+	for t.Kind() == reflect.Array {
+		dims = append(dims, t.Len())
+		t = t.Elem()
+	}
+
+	schema := WitnessFieldSchema{Name: name, Type: t.String(), ArrayDims: dims}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			schema.Fields = append(schema.Fields, buildWitnessFieldSchema(field.Name, field.Type))
+		}
+	}
+	return schema
+}
+
+// WitnessSchemaJSON describes the field layout of TxConstraints - names,
+// scalar types, and array dimensions, recursing into nested struct fields -
+// as JSON, so that tooling outside of Go can know how to build a matching
+// witness without hand-translating this file.
+func WitnessSchemaJSON() (string, error) {
+	root := buildWitnessFieldSchema("TxConstraints", reflect.TypeOf(TxConstraints{}))
+	b, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,49 @@
+package circuit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func findWitnessField(fields []WitnessFieldSchema, name string) *WitnessFieldSchema {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func TestWitnessSchemaJSONIncludesKnownFields(t *testing.T) {
+	schemaJSON, err := WitnessSchemaJSON()
+	require.NoError(t, err)
+
+	var root WitnessFieldSchema
+	require.NoError(t, json.Unmarshal([]byte(schemaJSON), &root))
+	require.Equal(t, "TxConstraints", root.Name)
+
+	txType := findWitnessField(root.Fields, "TxType")
+	require.NotNil(t, txType)
+	require.Empty(t, txType.ArrayDims)
+
+	nonce := findWitnessField(root.Fields, "Nonce")
+	require.NotNil(t, nonce)
+
+	accountsInfoBefore := findWitnessField(root.Fields, "AccountsInfoBefore")
+	require.NotNil(t, accountsInfoBefore)
+	require.Equal(t, []int{NbAccountsPerTx}, accountsInfoBefore.ArrayDims)
+
+	merkleProofsNftBefore := findWitnessField(root.Fields, "MerkleProofsNftBefore")
+	require.NotNil(t, merkleProofsNftBefore)
+	require.Equal(t, []int{NftMerkleLevels}, merkleProofsNftBefore.ArrayDims)
+
+	merkleProofsAccountBefore := findWitnessField(root.Fields, "MerkleProofsAccountBefore")
+	require.NotNil(t, merkleProofsAccountBefore)
+	require.Equal(t, []int{NbAccountsPerTx, AccountMerkleLevels}, merkleProofsAccountBefore.ArrayDims)
+
+	merkleProofsAccountAssetsBefore := findWitnessField(root.Fields, "MerkleProofsAccountAssetsBefore")
+	require.NotNil(t, merkleProofsAccountAssetsBefore)
+	require.Equal(t, []int{NbAccountsPerTx, NbAccountAssetsPerAccount, AssetMerkleLevels}, merkleProofsAccountAssetsBefore.ArrayDims)
+}
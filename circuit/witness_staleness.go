@@ -0,0 +1,31 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import "bytes"
+
+// IsWitnessStale reports whether oTx was built against an account root that
+// no longer matches currentRoot, e.g. because another tx committed in the
+// meantime. A sequencer should rebuild the witness rather than prove it, so
+// it doesn't waste a proving run that will fail the circuit's root checks.
+func IsWitnessStale(oTx *Tx, currentRoot []byte) bool {
+	if oTx == nil {
+		return true
+	}
+	return !bytes.Equal(oTx.AccountRootBefore, currentRoot)
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWitnessStale(t *testing.T) {
+	root := []byte{1, 2, 3}
+	tx := &Tx{AccountRootBefore: root}
+
+	require.False(t, IsWitnessStale(tx, root))
+	require.True(t, IsWitnessStale(tx, []byte{4, 5, 6}))
+	require.True(t, IsWitnessStale(nil, root))
+}
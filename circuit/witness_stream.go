@@ -0,0 +1,126 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+)
+
+// variableType is frontend's own (unexported) tVariable, rebuilt here so
+// this package can drive witness.Vector.ToAssignment directly: reflect.Type
+// of the Variable interface itself, used as the "leaf type" that marks
+// where a witness value belongs in a circuit struct.
+var variableType = reflect.TypeOf((*Variable)(nil)).Elem()
+
+// MarshalTxWitness streams tx's field elements to w using gnark's own
+// witness binary encoding (see github.com/consensys/gnark/backend/witness's
+// package doc: a length-prefixed sequence of big-endian field elements, no
+// schema). Unlike (*witness.Witness).MarshalBinary, which buffers the whole
+// encoding into a []byte first, this writes directly to w, so a large
+// block's witnesses can be streamed to disk (or across a socket to a
+// separate proving machine) without holding the encoded form in memory
+// alongside the TxConstraints it came from.
+func MarshalTxWitness(w io.Writer, tx *TxConstraints) (int64, error) {
+	wit, err := frontend.NewWitness(tx, ecc.BN254)
+	if err != nil {
+		return 0, err
+	}
+	return wit.Vector.WriteTo(w)
+}
+
+// UnmarshalTxWitness reads a stream written by MarshalTxWitness from r and
+// assigns its field elements back onto tx. tx must already have the same
+// shape (TxsCount-driven slice lengths, e.g. from GetZeroTxConstraint) as
+// the TxConstraints MarshalTxWitness was called with: gnark's binary
+// encoding carries only raw field elements in schema order, not the schema
+// itself, so the schema has to come from somewhere, and the target struct
+// is the natural source since decoding is going to populate exactly that
+// struct.
+func UnmarshalTxWitness(r io.Reader, tx *TxConstraints) error {
+	return unmarshalWitness(r, tx)
+}
+
+// NewZeroBlockWitness builds a BlockConstraints value fully assigned with
+// zero values, sized for txsCount transactions and gasAssetIds gas assets.
+// Unlike NewEmptyBlockCircuit (a compile-only shape template whose
+// placeholder fields are never assigned as a witness), every field here is
+// a real value, so the result is accepted by frontend.NewWitness: it's the
+// skeleton MarshalBlockWitness/UnmarshalBlockWitness callers should start
+// from before overwriting fields with a real block's values, or before
+// decoding a stream into it.
+func NewZeroBlockWitness(txsCount int, gasAssetIds []int64) *BlockConstraints {
+	block := &BlockConstraints{
+		BlockNumber:     0,
+		CreatedAt:       0,
+		OldStateRoot:    0,
+		NewStateRoot:    0,
+		BlockCommitment: 0,
+		Txs:             make([]TxConstraints, txsCount),
+		TxsCount:        txsCount,
+		Gas:             GetZeroGasConstraints(gasAssetIds),
+		GasAssetIds:     gasAssetIds,
+		GasAccountIndex: 0,
+	}
+	for i := 0; i < txsCount; i++ {
+		block.Txs[i] = GetZeroTxConstraint()
+	}
+	// GetZeroGasConstraints leaves OfferCanceledOrFinalized unset on each
+	// asset (it's only ever read from a real AccountAsset elsewhere), which
+	// frontend.NewWitness rejects as a missing assignment; fill it in here.
+	for i := range block.Gas.AccountInfoBefore.AssetsInfo {
+		block.Gas.AccountInfoBefore.AssetsInfo[i].OfferCanceledOrFinalized = 0
+	}
+	return block
+}
+
+// MarshalBlockWitness is MarshalTxWitness's block-level twin.
+func MarshalBlockWitness(w io.Writer, block *BlockConstraints) (int64, error) {
+	wit, err := frontend.NewWitness(block, ecc.BN254)
+	if err != nil {
+		return 0, err
+	}
+	return wit.Vector.WriteTo(w)
+}
+
+// UnmarshalBlockWitness is UnmarshalTxWitness's block-level twin: block
+// must already be shaped and assigned like the witness being read, e.g.
+// via NewZeroBlockWitness(txsCount, gasAssetIds).
+func UnmarshalBlockWitness(r io.Reader, block *BlockConstraints) error {
+	return unmarshalWitness(r, block)
+}
+
+func unmarshalWitness(r io.Reader, target frontend.Circuit) error {
+	skeleton, err := frontend.NewWitness(target, ecc.BN254)
+	if err != nil {
+		return err
+	}
+	wit, err := witness.New(ecc.BN254, skeleton.Schema)
+	if err != nil {
+		return err
+	}
+	if _, err := wit.Vector.ReadFrom(r); err != nil {
+		return err
+	}
+	wit.Vector.ToAssignment(target, variableType, false)
+	return nil
+}
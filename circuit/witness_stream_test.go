@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalUnmarshalTxWitnessRoundTrips(t *testing.T) {
+	tx := zeroTx()
+	tx.Nonce = 7
+	witness, err := SetTxWitness(tx)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := MarshalTxWitness(&buf, &witness)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+	encoded := append([]byte(nil), buf.Bytes()...)
+
+	decoded := GetZeroTxConstraint()
+	require.NoError(t, UnmarshalTxWitness(&buf, &decoded))
+
+	// UnmarshalTxWitness assigns field elements back onto decoded, whose
+	// leaf fields end up typed as fr.Element rather than the original
+	// int64/[]byte/*big.Int types SetTxWitness produced, so compare by
+	// re-marshaling instead of a structural Equal: re-encoding decoded
+	// should reproduce byte-for-byte what MarshalTxWitness produced.
+	var reencoded bytes.Buffer
+	_, err = MarshalTxWitness(&reencoded, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded.Bytes())
+}
+
+func TestUnmarshalTxWitnessRejectsTruncatedStream(t *testing.T) {
+	tx := zeroTx()
+	witness, err := SetTxWitness(tx)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = MarshalTxWitness(&buf, &witness)
+	require.NoError(t, err)
+
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()/2])
+	decoded := GetZeroTxConstraint()
+	require.Error(t, UnmarshalTxWitness(truncated, &decoded))
+}
+
+func TestMarshalUnmarshalBlockWitnessRoundTrips(t *testing.T) {
+	block := NewZeroBlockWitness(2, []int64{0})
+
+	var buf bytes.Buffer
+	_, err := MarshalBlockWitness(&buf, block)
+	require.NoError(t, err)
+	encoded := append([]byte(nil), buf.Bytes()...)
+
+	decoded := NewZeroBlockWitness(2, []int64{0})
+	require.NoError(t, UnmarshalBlockWitness(&buf, decoded))
+
+	var reencoded bytes.Buffer
+	_, err = MarshalBlockWitness(&reencoded, decoded)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded.Bytes())
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ValidateWitnessFieldElements walks every Variable assigned in w and reports
+// an error for the first one whose magnitude is not strictly less than the
+// BN254 scalar field modulus. Assigning a value >= the modulus silently wraps
+// during witness computation, producing a constraint that is satisfied for
+// the wrong reason; this is a test-time sanity check, not something the
+// circuit itself can enforce.
+func ValidateWitnessFieldElements(w TxConstraints) error {
+	modulus := fr.Modulus()
+	return walkWitnessFields(reflect.ValueOf(w), modulus, "TxConstraints")
+}
+
+func walkWitnessFields(v reflect.Value, modulus *big.Int, path string) error {
+	// Try to interpret this value as a scalar witness value first: *big.Int
+	// and []byte both have kinds (Ptr, Slice) that would otherwise be
+	// mistaken for composite types to recurse into.
+	if value, ok := witnessValueToBigInt(v); ok {
+		if value.Sign() < 0 || value.Cmp(modulus) >= 0 {
+			return fmt.Errorf("%s is out of field range: %s", path, value.String())
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkWitnessFields(v.Elem(), modulus, path)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if err := walkWitnessFields(v.Field(i), modulus, path+"."+field.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkWitnessFields(v.Index(i), modulus, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// witnessValueToBigInt converts the concrete value held by a Variable field
+// (int64, uint64, *big.Int, []byte, ...) to a big.Int, returning ok=false for
+// kinds that are not scalar witness values (e.g. an unset interface{} nil).
+func witnessValueToBigInt(v reflect.Value) (*big.Int, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint()), true
+	case reflect.String:
+		if value, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return value, true
+		}
+		return nil, false
+	default:
+		if !v.CanInterface() {
+			return nil, false
+		}
+		switch value := v.Interface().(type) {
+		case *big.Int:
+			if value == nil {
+				return nil, false
+			}
+			return value, true
+		case []byte:
+			return new(big.Int).SetBytes(value), true
+		default:
+			return nil, false
+		}
+	}
+}
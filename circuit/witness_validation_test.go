@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWitnessFieldElements(t *testing.T) {
+	tx := GetZeroTxConstraint()
+	require.NoError(t, ValidateWitnessFieldElements(tx))
+
+	overField := new(big.Int).Add(fr.Modulus(), big.NewInt(1))
+	tx.Nonce = overField
+	err := ValidateWitnessFieldElements(tx)
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package zp256 wraps secp256k1 (the curve Ethereum keys use, sometimes
+// written "P-256k" or informally "zp256" in this repo's conventions) ECDSA
+// verification for use outside the circuit.
+//
+// There is no in-circuit counterpart here: verifying an ECDSA signature
+// inside a gnark circuit requires non-native field arithmetic (secp256k1's
+// base field doesn't divide evenly into BN254's scalar field, the field
+// this repo's circuits are compiled over), which needs gnark's emulated/
+// non-native field package. That package (std/math/emulated, and the
+// std/signature/ecdsa gadget built on it) doesn't exist yet in the gnark
+// v0.7.0 this repo is pinned to - grepping std/ under the vendored gnark
+// module confirms only std/signature/eddsa is available. Adding a
+// non-native field emulation layer from scratch, or bumping gnark to a
+// version that ships one, is a much larger, separate decision than this
+// single gadget and isn't made here.
+//
+// What this package does provide is the verification half a future tx
+// type authorized by an Ethereum key would need off-circuit - e.g. a
+// sequencer or wallet checking a user-submitted secp256k1 signature before
+// it's ever turned into a witness - built on this repo's existing
+// go-ethereum dependency rather than adding a new one.
+package zp256
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VerifySignature reports whether signature (the 64-byte [R || S] encoding
+// go-ethereum's crypto.Sign produces, without the trailing recovery byte) is
+// a valid secp256k1 ECDSA signature over hash by the holder of pubKey.
+func VerifySignature(pubKey *ecdsa.PublicKey, hash, signature []byte) bool {
+	if pubKey == nil {
+		return false
+	}
+	return crypto.VerifySignature(crypto.CompressPubkey(pubKey), hash, signature)
+}
+
+// RecoverPublicKey recovers the secp256k1 public key that produced the
+// 65-byte [R || S || V] signature (go-ethereum's recoverable signature
+// format) over hash, the same way Ethereum recovers a transaction's sender.
+func RecoverPublicKey(hash, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != 65 {
+		return nil, errors.New("zp256: signature must be 65 bytes (R || S || V)")
+	}
+	return crypto.SigToPub(hash, signature)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package zp256
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifySignatureAcceptsGenuineSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256([]byte("zkbnb-crypto zp256 test message"))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !VerifySignature(&key.PublicKey, hash, sig[:64]) {
+		t.Fatal("expected a genuine signature to verify")
+	}
+}
+
+func TestVerifySignatureRejectsWrongKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256([]byte("zkbnb-crypto zp256 test message"))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if VerifySignature(&other.PublicKey, hash, sig[:64]) {
+		t.Fatal("expected verification against the wrong key to fail")
+	}
+}
+
+func TestVerifySignatureRejectsTamperedHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256([]byte("zkbnb-crypto zp256 test message"))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedHash := crypto.Keccak256([]byte("a different message"))
+	if VerifySignature(&key.PublicKey, tamperedHash, sig[:64]) {
+		t.Fatal("expected verification against a tampered hash to fail")
+	}
+}
+
+func TestRecoverPublicKeyMatchesSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := crypto.Keccak256([]byte("zkbnb-crypto zp256 test message"))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := RecoverPublicKey(hash, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.Equal(&key.PublicKey) {
+		t.Fatal("recovered public key does not match the signer")
+	}
+}
+
+func TestRecoverPublicKeyRejectsShortSignature(t *testing.T) {
+	hash := crypto.Keccak256([]byte("zkbnb-crypto zp256 test message"))
+	if _, err := RecoverPublicKey(hash, make([]byte, 64)); err == nil {
+		t.Fatal("expected an error for a 64-byte (non-recoverable) signature")
+	}
+}
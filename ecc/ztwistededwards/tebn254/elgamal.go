@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ElGamalCiphertext is a lifted-ElGamal encryption of a small non-negative
+// value v over the curve: C1 = r*G, C2 = v*G + r*pk, for a random scalar r.
+// Because the value is encoded in the exponent, decryption recovers v*G and
+// then brute-forces the discrete log against a known upper bound.
+type ElGamalCiphertext struct {
+	C1 *Point
+	C2 *Point
+}
+
+// ElGamalEncrypt encrypts value under pk, returning a fresh ciphertext each
+// call (the randomizer r is drawn via RandomValue).
+func ElGamalEncrypt(pk *Point, value uint64) *ElGamalCiphertext {
+	r := RandomValue()
+	c1 := ScalarBaseMul(r)
+	vG := ScalarBaseMul(new(big.Int).SetUint64(value))
+	rPk := ScalarMul(pk, r)
+	c2 := Add(vG, rPk)
+	return &ElGamalCiphertext{C1: c1, C2: c2}
+}
+
+// ReRandomize refreshes ciphertext's encoding of its plaintext under pk using
+// a fresh randomizer r, without learning or changing the plaintext: C1' =
+// C1 + r*G, C2' = C2 + r*pk. The result decrypts to the same value as
+// ciphertext under the same sk, but is unlinkable to it by anyone who
+// doesn't know r, which is what lets a service publish a migrated ciphertext
+// without revealing that it corresponds to the same balance as before.
+func ReRandomize(ciphertext *ElGamalCiphertext, pk *Point, r *big.Int) *ElGamalCiphertext {
+	if ciphertext == nil || ciphertext.C1 == nil || ciphertext.C2 == nil {
+		return nil
+	}
+	rG := ScalarBaseMul(r)
+	rPk := ScalarMul(pk, r)
+	return &ElGamalCiphertext{
+		C1: Add(ciphertext.C1, rG),
+		C2: Add(ciphertext.C2, rPk),
+	}
+}
+
+// ElGamalDecrypt recovers the plaintext value from ciphertext using sk,
+// the private key paired with the public key it was encrypted under. Because
+// ElGamal over a curve only yields v*G, not v itself, the search is bounded:
+// it brute-forces candidate values in [0, max] and returns an error if none
+// of them matches, which also rejects any value that exceeds max.
+func ElGamalDecrypt(ciphertext *ElGamalCiphertext, sk *big.Int, max uint64) (uint64, error) {
+	if ciphertext == nil || ciphertext.C1 == nil || ciphertext.C2 == nil {
+		return 0, errors.New("ciphertext should not be nil")
+	}
+	skC1 := ScalarMul(ciphertext.C1, sk)
+	vG := Add(ciphertext.C2, Neg(skC1))
+	candidate := ZeroPoint()
+	for v := uint64(0); v <= max; v++ {
+		if candidate.X.Equal(&vG.X) && candidate.Y.Equal(&vG.Y) {
+			return v, nil
+		}
+		candidate = Add(candidate, G)
+	}
+	return 0, errors.New("decrypted value exceeds max")
+}
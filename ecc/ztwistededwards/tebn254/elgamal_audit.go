@@ -0,0 +1,60 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import "math/big"
+
+// DualCiphertext is a value encrypted independently to two public keys: the
+// owning user's, and an optional auditor's. Both ciphertexts encode the same
+// plaintext, each under its own randomizer, so neither ciphertext alone
+// links the other to a specific plaintext without the matching secret key.
+type DualCiphertext struct {
+	UserCiphertext    *ElGamalCiphertext
+	AuditorCiphertext *ElGamalCiphertext
+}
+
+// DualEncrypt encrypts value under both userPk and auditorPk, using an
+// independent randomizer for each ciphertext, so an auditor holding the
+// matching secret key can recover balances (via Decryptor) without the user
+// ever revealing their own secret key. Pass a nil auditorPk to produce a
+// DualCiphertext with AuditorCiphertext left nil, for accounts that haven't
+// opted into auditability.
+func DualEncrypt(userPk, auditorPk *Point, value uint64) *DualCiphertext {
+	dual := &DualCiphertext{UserCiphertext: ElGamalEncrypt(userPk, value)}
+	if auditorPk != nil {
+		dual.AuditorCiphertext = ElGamalEncrypt(auditorPk, value)
+	}
+	return dual
+}
+
+// DualEncryptWithRandomizers is DualEncrypt's deterministic twin: it takes
+// the randomizers explicitly instead of drawing them, so a prover building a
+// witness for AssertDualCiphertextsEncryptSameValue can supply ciphertexts
+// whose randomizers it still knows.
+func DualEncryptWithRandomizers(userPk, auditorPk *Point, value uint64, rUser, rAuditor *big.Int) *DualCiphertext {
+	vG := ScalarBaseMul(new(big.Int).SetUint64(value))
+	userCt := &ElGamalCiphertext{
+		C1: ScalarBaseMul(rUser),
+		C2: Add(vG, ScalarMul(userPk, rUser)),
+	}
+	auditorCt := &ElGamalCiphertext{
+		C1: ScalarBaseMul(rAuditor),
+		C2: Add(vG, ScalarMul(auditorPk, rAuditor)),
+	}
+	return &DualCiphertext{UserCiphertext: userCt, AuditorCiphertext: auditorCt}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDualEncryptBothCiphertextsDecryptToSameValue(t *testing.T) {
+	userSk := big.NewInt(111)
+	auditorSk := big.NewInt(222)
+	userPk := ScalarBaseMul(userSk)
+	auditorPk := ScalarBaseMul(auditorSk)
+
+	dual := DualEncrypt(userPk, auditorPk, 77)
+
+	userValue, err := ElGamalDecrypt(dual.UserCiphertext, userSk, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditorValue, err := ElGamalDecrypt(dual.AuditorCiphertext, auditorSk, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userValue != 77 || auditorValue != 77 {
+		t.Fatalf("expected both ciphertexts to decrypt to 77, got user=%d auditor=%d", userValue, auditorValue)
+	}
+}
+
+func TestDualEncryptWithoutAuditorPkLeavesAuditorCiphertextNil(t *testing.T) {
+	userPk := ScalarBaseMul(big.NewInt(111))
+	dual := DualEncrypt(userPk, nil, 77)
+	if dual.AuditorCiphertext != nil {
+		t.Fatal("expected AuditorCiphertext to be nil when no auditor pk is given")
+	}
+}
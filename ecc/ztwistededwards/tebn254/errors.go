@@ -22,6 +22,7 @@ import (
 )
 
 var (
-	ErrMapToGroup       = errors.New("Failed to Hash-to-point.")
-	ErrInvalidPointSize = errors.New("err: invalid point size")
+	ErrMapToGroup         = errors.New("Failed to Hash-to-point.")
+	ErrInvalidPointSize   = errors.New("err: invalid point size")
+	ErrPointNotInSubGroup = errors.New("err: point is not in the prime-order subgroup")
 )
@@ -71,6 +71,17 @@ func ToBytes(p *Point) []byte {
 	return p.Marshal()
 }
 
+// WritePointIntoBufNative is the native twin of the circuit's WritePointIntoBuf
+// gadget: it writes p's X coordinate followed by its Y coordinate into buf,
+// each as a 32-byte big-endian field element, matching the order the circuit
+// absorbs a point's coordinates into a MiMC hash.
+func WritePointIntoBufNative(buf *bytes.Buffer, p *Point) {
+	x := p.X.ToBigIntRegular(new(big.Int))
+	y := p.Y.ToBigIntRegular(new(big.Int))
+	buf.Write(x.FillBytes(make([]byte, 32)))
+	buf.Write(y.FillBytes(make([]byte, 32)))
+}
+
 func ToString(p *Point) string {
 	return base64.StdEncoding.EncodeToString(p.Marshal())
 }
@@ -103,6 +114,18 @@ func IsInSubGroup(p *Point) bool {
 	return IsZero(res)
 }
 
+// ValidateSubGroup is a native pre-check that rejects points lying in the small
+// cofactor subgroup (or off-curve entirely), returning ErrPointNotInSubGroup if
+// the point is not a member of the prime-order subgroup. It is meant to be run
+// against any externally-supplied point (e.g. a parsed public key or signature
+// R) before it is trusted as a circuit witness.
+func ValidateSubGroup(p *Point) error {
+	if !IsInSubGroup(p) {
+		return ErrPointNotInSubGroup
+	}
+	return nil
+}
+
 func MapToGroup(seed string) (H *Point, err error) {
 	var (
 		i      int
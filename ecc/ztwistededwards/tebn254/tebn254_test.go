@@ -86,6 +86,30 @@ func TestAdd(t *testing.T) {
 	fmt.Println(A1)
 }
 
+func TestValidateSubGroupRejectsSmallOrderPoint(t *testing.T) {
+	// (0, -1) satisfies a*x^2+y^2 = 1+d*x^2*y^2 (since x = 0) and is a point of
+	// order 2, i.e. it lies in the cofactor-8 subgroup but not in the
+	// prime-order subgroup.
+	smallOrderPoint := &Point{}
+	smallOrderPoint.X.SetZero()
+	smallOrderPoint.Y.SetOne()
+	smallOrderPoint.Y.Neg(&smallOrderPoint.Y)
+
+	if IsInSubGroup(smallOrderPoint) {
+		t.Fatalf("expected small-order point to not be in the prime-order subgroup")
+	}
+	if err := ValidateSubGroup(smallOrderPoint); err != ErrPointNotInSubGroup {
+		t.Fatalf("expected ErrPointNotInSubGroup, got %v", err)
+	}
+
+	if !IsInSubGroup(G) {
+		t.Fatalf("expected base generator to be in the prime-order subgroup")
+	}
+	if err := ValidateSubGroup(G); err != nil {
+		t.Fatalf("expected base generator to validate, got %v", err)
+	}
+}
+
 func TestAssign(t *testing.T) {
 	//A := ScalarBaseMul(big.NewInt(230928302))
 	//fmt.Println(A.X)
@@ -0,0 +1,106 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// hdMasterKey is the HMAC key BIP32 and SLIP-0010 both use to derive a
+// master key from a seed; reusing it (rather than inventing our own) keeps
+// this derivation instantly recognizable to anyone who already knows that
+// scheme.
+var hdMasterKey = []byte("Bitcoin seed")
+
+// ExtendedKey is one node of an HD derivation tree: an eddsa private key
+// plus the chain code needed to derive its children. Baby Jubjub has no
+// curve addition trick analogous to secp256k1's public-child-key
+// derivation, so - like SLIP-0010 for ed25519 - every child here is
+// derived from its parent's private key, not its public key. There is no
+// such thing as non-hardened derivation in this scheme; every index is
+// effectively hardened.
+type ExtendedKey struct {
+	PrivateKey *curve.PrivateKey
+	ChainCode  [32]byte
+}
+
+// NewMasterKey derives the root ExtendedKey of an HD tree from seed (e.g.
+// a BIP39 mnemonic's seed bytes). The same seed always yields the same
+// tree.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, hdMasterKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return extendedKeyFromSum(sum)
+}
+
+// DeriveChild derives the index'th child of k.
+func (k *ExtendedKey) DeriveChild(index uint32) (*ExtendedKey, error) {
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+
+	mac := hmac.New(sha512.New, k.ChainCode[:])
+	mac.Write(k.PrivateKey.Bytes())
+	mac.Write(indexBytes[:])
+	sum := mac.Sum(nil)
+	return extendedKeyFromSum(sum)
+}
+
+// DerivePath walks path from k, deriving one child per index in order, and
+// returns the eddsa private key at the end of the path. A custodian can
+// hand out a distinct path per sub-account and regenerate every one of
+// them from the single master seed.
+func (k *ExtendedKey) DerivePath(path []uint32) (*curve.PrivateKey, error) {
+	current := k
+	for _, index := range path {
+		child, err := current.DeriveChild(index)
+		if err != nil {
+			return nil, err
+		}
+		current = child
+	}
+	return current.PrivateKey, nil
+}
+
+// DeriveHDPrivateKey derives the eddsa private key at path below the HD
+// tree rooted at masterSeed in one call.
+func DeriveHDPrivateKey(masterSeed []byte, path []uint32) (*curve.PrivateKey, error) {
+	master, err := NewMasterKey(masterSeed)
+	if err != nil {
+		return nil, err
+	}
+	return master.DerivePath(path)
+}
+
+func extendedKeyFromSum(sum []byte) (*ExtendedKey, error) {
+	if len(sum) != 64 {
+		return nil, errors.New("unexpected hmac output size")
+	}
+	sk, err := GenerateEddsaPrivateKey(sum[:32])
+	if err != nil {
+		return nil, err
+	}
+	var chainCode [32]byte
+	copy(chainCode[:], sum[32:])
+	return &ExtendedKey{PrivateKey: sk, ChainCode: chainCode}, nil
+}
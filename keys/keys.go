@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package keys derives the L2 eddsa keypair a wallet needs to sign zkBNB
+// txs. A user shouldn't have to remember a second secret: the same zkSync
+// has a wallet sign a fixed message with its L1 key, then treats that
+// signature as the entropy for an otherwise ordinary eddsa keypair, so the
+// L2 key is always recoverable from the L1 account alone.
+package keys
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// EthSignatureMessage is the fixed message an L1 wallet signs during
+// onboarding. DeriveFromEthSignature only ever derives a key from a
+// signature over this exact message; signing anything else will not
+// reproduce the account's L2 key.
+const EthSignatureMessage = "Access zkBNB account.\n\nOnly sign this message for a trusted client!"
+
+// GenerateEddsaPrivateKey deterministically derives the L2 eddsa private
+// key from seed. The same seed always yields the same key.
+func GenerateEddsaPrivateKey(seed []byte) (*curve.PrivateKey, error) {
+	return curve.GenerateEddsaPrivateKey(string(seed))
+}
+
+// DeriveFromEthSignature derives the L2 eddsa private key from sig, the 65
+// byte L1 signature over EthSignatureMessage. Hashing sig down to 32 bytes
+// before handing it to GenerateEddsaPrivateKey keeps the derivation
+// deterministic while matching the fixed-size seed GenerateKey expects.
+func DeriveFromEthSignature(sig []byte) (*curve.PrivateKey, error) {
+	if len(sig) != 65 {
+		return nil, errors.New("signature should be 65 bytes")
+	}
+	seed := crypto.Keccak256(sig)
+	return GenerateEddsaPrivateKey(seed)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEddsaPrivateKeyIsDeterministic(t *testing.T) {
+	sk1, err := GenerateEddsaPrivateKey([]byte("a fixed seed"))
+	require.NoError(t, err)
+	sk2, err := GenerateEddsaPrivateKey([]byte("a fixed seed"))
+	require.NoError(t, err)
+	require.Equal(t, sk1.Bytes(), sk2.Bytes())
+
+	sk3, err := GenerateEddsaPrivateKey([]byte("a different seed"))
+	require.NoError(t, err)
+	require.NotEqual(t, sk1.Bytes(), sk3.Bytes())
+}
+
+func TestDeriveFromEthSignatureRejectsWrongLength(t *testing.T) {
+	_, err := DeriveFromEthSignature(make([]byte, 64))
+	require.EqualError(t, err, "signature should be 65 bytes")
+}
+
+func TestDeriveFromEthSignatureIsDeterministic(t *testing.T) {
+	sig1 := make([]byte, 65)
+	for i := range sig1 {
+		sig1[i] = byte(i)
+	}
+	sk1, err := DeriveFromEthSignature(sig1)
+	require.NoError(t, err)
+	sk2, err := DeriveFromEthSignature(sig1)
+	require.NoError(t, err)
+	require.Equal(t, sk1.Bytes(), sk2.Bytes())
+
+	// Test vector: a fixed all-0x00..0x40 signature must always derive the
+	// same public key, so client and mobile implementations stay compatible.
+	require.Equal(t,
+		"cd648de3cf600ebabf254d399699a057361c5b3deace0ca19eea0ba83e815927",
+		hex.EncodeToString(sk1.PublicKey.Bytes()),
+	)
+
+	sig2 := make([]byte, 65)
+	for i := range sig2 {
+		sig2[i] = byte(64 - i)
+	}
+	sk3, err := DeriveFromEthSignature(sig2)
+	require.NoError(t, err)
+	require.NotEqual(t, sk1.Bytes(), sk3.Bytes())
+}
@@ -34,8 +34,25 @@ const (
 
 var (
 	NilHash = common.FromHex("01ef55cdf3b9b0d65e6fb6317f79627534d971fd96c811281af618c0028d5e7a")
+	// ErrLeafCollidesWithNilHash is returned when a real leaf value happens
+	// to equal NilHash, the sentinel value this tree uses to represent an
+	// empty/default leaf. Such a leaf would be indistinguishable from an
+	// unset one once inserted, so construction must reject it explicitly
+	// rather than silently treating the tree as sparser than it really is.
+	ErrLeafCollidesWithNilHash = errors.New("[merkleTree] leaf value collides with NilHash")
 )
 
+// ValidateLeafValue reports ErrLeafCollidesWithNilHash if value is equal to
+// NilHash. Callers constructing a leaf from real data (e.g. before calling
+// CreateLeafNode) should check this first, since the tree has no other way
+// to tell a genuine leaf with this value apart from an empty one.
+func ValidateLeafValue(value []byte) error {
+	if bytes.Equal(value, NilHash) {
+		return ErrLeafCollidesWithNilHash
+	}
+	return nil
+}
+
 /*
 	Tree: sparse merkle tree
 */
@@ -240,6 +240,25 @@ func TestNewEmptyTree(t *testing.T) {
 	assert.Equal(t, true, isValid, "invalid proof")
 }
 
+// TestValidateLeafValueRejectsNilHashCollision constructs a "real" leaf
+// value that happens to collide with the NilHash sentinel and checks that
+// ValidateLeafValue flags it, rather than letting it silently be inserted
+// and become indistinguishable from an empty leaf.
+func TestValidateLeafValueRejectsNilHashCollision(t *testing.T) {
+	collidingLeaf := make([]byte, len(NilHash))
+	copy(collidingLeaf, NilHash)
+	err := ValidateLeafValue(collidingLeaf)
+	assert.Equal(t, ErrLeafCollidesWithNilHash, err)
+}
+
+func TestValidateLeafValueAcceptsRealLeaf(t *testing.T) {
+	hFunc := mimc.NewMiMC()
+	hFunc.Write([]byte("1111"))
+	hashVal := hFunc.Sum(nil)
+	err := ValidateLeafValue(hashVal)
+	assert.Nil(t, err)
+}
+
 func TestNewTreeByMapAndUpdate(t *testing.T) {
 	// by map
 	hFunc := mimc.NewMiMC()
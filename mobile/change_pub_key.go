@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mobile
+
+import (
+	"encoding/hex"
+
+	"github.com/bnb-chain/zkbnb-crypto/wasm/txtypes"
+)
+
+// SignChangePubKey builds a ChangePubKeyTxInfo from segmentStr. It takes no
+// seed: a ChangePubKey isn't signed with the account's eddsa key (that's
+// the key being replaced). Call ChangePubKeyStructHash with the same
+// segmentStr to get the digest an L1 wallet signs for AuthModeECDSA.
+func SignChangePubKey(segmentStr string) (string, error) {
+	txInfo, err := txtypes.ConstructChangePubKeyTxInfo(segmentStr)
+	if err != nil {
+		return "", err
+	}
+	return marshalJSON(txInfo)
+}
+
+// ChangePubKeyStructHash returns the hex-encoded EIP-712 digest of the
+// ChangePubKeyTxInfo built from segmentStr (the same segment JSON
+// SignChangePubKey takes), ready for an L1 wallet to sign as that tx's
+// EthSignature.
+func ChangePubKeyStructHash(segmentStr string) (string, error) {
+	txInfo, err := txtypes.ConstructChangePubKeyTxInfo(segmentStr)
+	if err != nil {
+		return "", err
+	}
+	digest, err := txtypes.EIP712StructHash(txInfo)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest), nil
+}
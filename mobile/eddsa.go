@@ -0,0 +1,64 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mobile
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// Sign signs msg with the eddsa key derived from seed, returning the
+// hex-encoded signature.
+func Sign(seed string, msg string) (string, error) {
+	sk, err := curve.GenerateEddsaPrivateKey(seed)
+	if err != nil {
+		return "", err
+	}
+	signature, err := sk.Sign([]byte(msg), mimc.NewMiMC())
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(signature), nil
+}
+
+// Verify reports whether signature (hex-encoded) is a valid eddsa signature
+// over msg by the holder of pubKey (hex-encoded).
+func Verify(pubKey string, signature string, msg string) (bool, error) {
+	pkBytes, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return false, err
+	}
+	pk := eddsa.PublicKey{}
+	size, err := pk.SetBytes(pkBytes)
+	if err != nil {
+		return false, err
+	}
+	if size != 32 {
+		return false, errors.New("invalid public key")
+	}
+	sigBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, err
+	}
+	return pk.Verify(sigBytes, []byte(msg), mimc.NewMiMC())
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package mobile is the gomobile-bind-friendly counterpart to wasm/src: the
+// same seed-in/segment-in, signed-tx-JSON-out API, but as plain
+// string-in/string-out Go functions returning (string, error) instead of
+// js.Func closures over interface{}. gomobile bind can only export a
+// restricted subset of Go - basic types, []byte, and (result, error) pairs,
+// no js.Value/interface{} - so this package deliberately doesn't import
+// syscall/js, even though every function here wraps the exact same
+// wasm/txtypes constructors wasm/src does.
+package mobile
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+// GenerateKeyPair derives the eddsa key pair for seed and returns it as a
+// JSON object: {"privateKey": "...", "publicKey": "..."}, both hex-encoded.
+func GenerateKeyPair(seed string) (string, error) {
+	sk, err := curve.GenerateEddsaPrivateKey(seed)
+	if err != nil {
+		return "", err
+	}
+	return marshalJSON(map[string]string{
+		"privateKey": hex.EncodeToString(sk.Bytes()),
+		"publicKey":  hex.EncodeToString(sk.PublicKey.Bytes()),
+	})
+}
+
+// GetPublicKey returns seed's hex-encoded compressed eddsa public key.
+func GetPublicKey(seed string) (string, error) {
+	sk, err := curve.GenerateEddsaPrivateKey(seed)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sk.PublicKey.Bytes()), nil
+}
+
+func marshalJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
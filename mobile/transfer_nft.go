@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mobile
+
+import (
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+	"github.com/bnb-chain/zkbnb-crypto/wasm/txtypes"
+)
+
+// SignTransferNft signs a transfer-nft tx described by segmentStr with the
+// eddsa key derived from seed, returning the signed TransferNftTxInfo as
+// JSON.
+func SignTransferNft(seed string, segmentStr string) (string, error) {
+	sk, err := curve.GenerateEddsaPrivateKey(seed)
+	if err != nil {
+		return "", err
+	}
+	txInfo, err := txtypes.ConstructTransferNftTxInfo(sk, segmentStr)
+	if err != nil {
+		return "", err
+	}
+	return marshalJSON(txInfo)
+}
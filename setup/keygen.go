@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package setup wraps circuit compilation and Groth16 setup (both already
+// provided by circuit/solidity) with the file-writing and bookkeeping an
+// operator otherwise hand-rolls: versioned output filenames and checksums
+// for the R1CS, proving key, and verifying key a given circuit/block-size
+// combination needs.
+package setup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark/frontend"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit"
+	"github.com/bnb-chain/zkbnb-crypto/circuit/solidity"
+)
+
+// CircuitType selects which circuit GenerateKeys compiles and runs setup
+// against.
+type CircuitType string
+
+// CircuitTypeBlock is the VerifyBlock circuit (circuit.BlockConstraints),
+// the only circuit this repo actually proves in its solidity prover
+// (circuit/solidity/zkbnb_test.go builds and proves a BlockConstraints,
+// never a bare TxConstraints); it is the only CircuitType GenerateKeys
+// supports today.
+const CircuitTypeBlock CircuitType = "block"
+
+// KeyManifest records the on-disk artifacts GenerateKeys wrote for one
+// circuit/blockSize/gasAssetCount combination: each file's path plus a
+// hex-encoded SHA-256 checksum, so a loader reading the files back later
+// can confirm none of the three have drifted from what GenerateKeys
+// produced together before trusting them for proving or verification.
+type KeyManifest struct {
+	CircuitType   CircuitType
+	BlockSize     int
+	GasAssetCount int
+	// Fingerprint is the circuit's solidity.CircuitFingerprint, also
+	// embedded in each artifact's filename so distinct circuit shapes
+	// (e.g. after a constraint change) never collide on disk.
+	Fingerprint string
+
+	R1CSFile   string
+	R1CSSha256 string
+
+	ProvingKeyFile   string
+	ProvingKeySha256 string
+
+	VerifyingKeyFile   string
+	VerifyingKeySha256 string
+}
+
+// GenerateKeys compiles the circuit selected by circuitType at blockSize
+// (with gasAssetCount gas assets), runs a fresh Groth16 setup against it,
+// and writes the compiled R1CS, proving key, and verifying key to
+// outputDir. Each filename is versioned by the circuit's fingerprint, so
+// operators don't hand-roll setup scripts or risk serving a key pair
+// mismatched to the circuit it was generated from.
+func GenerateKeys(circuitType CircuitType, blockSize, gasAssetCount int, outputDir string) (*KeyManifest, error) {
+	tpl, err := buildCircuitTemplate(circuitType, blockSize, gasAssetCount)
+	if err != nil {
+		return nil, err
+	}
+	return generateKeysForCircuit(tpl, circuitType, blockSize, gasAssetCount, outputDir)
+}
+
+// generateKeysForCircuit does the fingerprinting, setup, and file-writing
+// GenerateKeys promises, against an already-built circuit template. Split
+// out from GenerateKeys so tests can exercise this logic against a small
+// synthetic circuit instead of paying the real (multi-minute) block circuit
+// setup cost on every test run, the same way circuit/solidity's own tests
+// use a tiny fingerprintCircuit rather than a real BlockConstraints.
+func generateKeysForCircuit(tpl frontend.Circuit, circuitType CircuitType, blockSize, gasAssetCount int, outputDir string) (*KeyManifest, error) {
+	fingerprint, err := solidity.CircuitFingerprint(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprint circuit: %w", err)
+	}
+
+	ccs, pk, vk, err := solidity.Setup(solidity.Groth16Backend, tpl)
+	if err != nil {
+		return nil, fmt.Errorf("groth16 setup: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output dir %s: %w", outputDir, err)
+	}
+
+	manifest := &KeyManifest{
+		CircuitType:   circuitType,
+		BlockSize:     blockSize,
+		GasAssetCount: gasAssetCount,
+		Fingerprint:   fingerprint,
+	}
+	if manifest.R1CSFile, manifest.R1CSSha256, err = writeArtifact(outputDir, circuitType, blockSize, fingerprint, "r1cs", ccs); err != nil {
+		return nil, err
+	}
+	if manifest.ProvingKeyFile, manifest.ProvingKeySha256, err = writeArtifact(outputDir, circuitType, blockSize, fingerprint, "pk", pk); err != nil {
+		return nil, err
+	}
+	if manifest.VerifyingKeyFile, manifest.VerifyingKeySha256, err = writeArtifact(outputDir, circuitType, blockSize, fingerprint, "vk", vk); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func buildCircuitTemplate(circuitType CircuitType, blockSize, gasAssetCount int) (frontend.Circuit, error) {
+	switch circuitType {
+	case CircuitTypeBlock:
+		return circuit.NewEmptyBlockCircuit(blockSize, gasAssetCount), nil
+	default:
+		return nil, fmt.Errorf("unsupported circuit type: %q", circuitType)
+	}
+}
+
+func writeArtifact(outputDir string, circuitType CircuitType, blockSize int, fingerprint, kind string, artifact io.WriterTo) (path string, checksum string, err error) {
+	filename := fmt.Sprintf("%s-%d-%s.%s", circuitType, blockSize, fingerprint[:16], kind)
+	path = filepath.Join(outputDir, filename)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", "", fmt.Errorf("create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := artifact.WriteTo(io.MultiWriter(f, h)); err != nil {
+		return "", "", fmt.Errorf("write %s: %w", path, err)
+	}
+	return path, hex.EncodeToString(h.Sum(nil)), nil
+}
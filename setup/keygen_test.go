@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package setup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// tinyCircuit is a minimal stand-in for the real block circuit, used to
+// keep generateKeysForCircuit's file-writing/checksum logic fast to test,
+// the same way circuit/solidity's own tests use a tiny fingerprintCircuit
+// rather than a real BlockConstraints.
+type tinyCircuit struct {
+	A, B, C frontend.Variable
+}
+
+func (c *tinyCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.A, c.B), c.C)
+	return nil
+}
+
+func TestGenerateKeysForCircuitWritesChecksummedArtifacts(t *testing.T) {
+	outputDir := t.TempDir()
+
+	manifest, err := generateKeysForCircuit(&tinyCircuit{}, CircuitTypeBlock, 1, 1, outputDir)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, manifest.Fingerprint)
+	require.Equal(t, CircuitTypeBlock, manifest.CircuitType)
+	require.Equal(t, 1, manifest.BlockSize)
+	require.Equal(t, 1, manifest.GasAssetCount)
+	for _, artifact := range []struct {
+		path, wantSum string
+	}{
+		{manifest.R1CSFile, manifest.R1CSSha256},
+		{manifest.ProvingKeyFile, manifest.ProvingKeySha256},
+		{manifest.VerifyingKeyFile, manifest.VerifyingKeySha256},
+	} {
+		data, err := os.ReadFile(artifact.path)
+		require.NoError(t, err)
+		require.NotEmpty(t, data)
+		sum := sha256.Sum256(data)
+		require.Equal(t, artifact.wantSum, hex.EncodeToString(sum[:]))
+	}
+}
+
+func TestGenerateKeysRejectsUnsupportedCircuitType(t *testing.T) {
+	_, err := GenerateKeys(CircuitType("tx"), 1, 1, t.TempDir())
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package tree
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+)
+
+type treeProofConstraints struct {
+	Leaf       frontend.Variable
+	ProofSet   [4]frontend.Variable
+	Helper     [4]frontend.Variable
+	ExpectRoot frontend.Variable
+}
+
+func (circuit treeProofConstraints) Define(api frontend.API) error {
+	hFunc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	node := circuit.Leaf
+	for i := 0; i < len(circuit.ProofSet); i++ {
+		api.AssertIsBoolean(circuit.Helper[i])
+		d1 := api.Select(circuit.Helper[i], circuit.ProofSet[i], node)
+		d2 := api.Select(circuit.Helper[i], node, circuit.ProofSet[i])
+		hFunc.Write(d1, d2)
+		node = hFunc.Sum()
+		hFunc.Reset()
+	}
+	api.AssertIsEqual(node, circuit.ExpectRoot)
+	return nil
+}
+
+// TestSparseMerkleTreeProofIsAcceptedByCircuitFold checks that a proof this
+// package's SparseMerkleTree produces folds up to the same root inside a
+// gnark circuit using the exact fold VerifyMerkleProof/UpdateMerkleProof
+// perform (Select on helper, MiMC hash), confirming the proof this package
+// hands out is directly usable as circuit witness data with no translation.
+func TestSparseMerkleTreeProofIsAcceptedByCircuitFold(t *testing.T) {
+	smt, err := NewSparseMerkleTree(4, NewMemoryStore())
+	require.NoError(t, err)
+	leaf := big.NewInt(7).FillBytes(make([]byte, 32))
+	require.NoError(t, smt.Set(3, leaf))
+
+	proofSet, helper, err := smt.Prove(3)
+	require.NoError(t, err)
+	require.True(t, VerifyProof(smt.Root(), leaf, proofSet, helper))
+
+	assert := test.NewAssert(t)
+	var circuit treeProofConstraints
+	witness := treeProofConstraints{
+		Leaf:       new(big.Int).SetBytes(leaf),
+		ExpectRoot: new(big.Int).SetBytes(smt.Root()),
+	}
+	for i := range proofSet {
+		witness.ProofSet[i] = new(big.Int).SetBytes(proofSet[i])
+		witness.Helper[i] = helper[i]
+	}
+	assert.SolvingSucceeded(
+		&circuit, &witness, test.WithBackends(backend.GROTH16), test.WithCurves(ecc.BN254),
+		test.WithCompileOpts(frontend.IgnoreUnconstrainedInputs()))
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tree
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ProofToBigIntSlice converts a Prove() proofSet into the []*big.Int shape
+// callers copy into a circuit's fixed-size Merkle proof field (e.g.
+// BlockConstraints/GasConstraints's [AccountMerkleLevels]Variable,
+// [AssetMerkleLevels]Variable, or [NftMerkleLevels]Variable in
+// circuit/types.go): since frontend.Variable is just interface{}, a
+// *big.Int assigns into those fields element by element the same way
+// SetGasWitness/SetTxWitness already assign proof bytes into them, without
+// this package needing to import gnark's frontend. levels must equal
+// len(proofSet); it's taken explicitly so a mismatch (e.g. a proof from the
+// wrong tree) is caught here instead of silently under/over-filling a
+// circuit witness array.
+func ProofToBigIntSlice(proofSet [][]byte, levels int) ([]*big.Int, error) {
+	if len(proofSet) != levels {
+		return nil, fmt.Errorf("tree: expected %d proof levels, got %d", levels, len(proofSet))
+	}
+	result := make([]*big.Int, levels)
+	for i, node := range proofSet {
+		result[i] = new(big.Int).SetBytes(node)
+	}
+	return result, nil
+}
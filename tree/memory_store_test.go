@@ -0,0 +1,29 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreGetPutDeleteHas(t *testing.T) {
+	store := NewMemoryStore()
+
+	_, err := store.Get([]byte("k"))
+	require.Equal(t, ErrNotFound, err)
+	has, err := store.Has([]byte("k"))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, store.Put([]byte("k"), []byte("v")))
+	value, err := store.Get([]byte("k"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v"), value)
+	has, err = store.Has([]byte("k"))
+	require.NoError(t, err)
+	require.True(t, has)
+
+	require.NoError(t, store.Delete([]byte("k")))
+	_, err = store.Get([]byte("k"))
+	require.Equal(t, ErrNotFound, err)
+}
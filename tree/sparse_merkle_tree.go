@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/circuit/types"
+)
+
+// SparseMerkleTree is a MiMC sparse Merkle tree over 2^depth leaves, backed
+// by a pluggable KVStore. depth should match whichever circuit tree is
+// being modeled off-chain (circuit.AccountMerkleLevels, AssetMerkleLevels,
+// or NftMerkleLevels); this package imports circuit/types for its native
+// MiMC helpers but not circuit itself, so callers pass the level count
+// directly.
+type SparseMerkleTree struct {
+	depth      int
+	store      KVStore
+	zeroHashes [][]byte // zeroHashes[i]: root of an empty subtree of height i
+	root       []byte
+}
+
+// nodeKey addresses an internal node by (level, index within that level),
+// the same two coordinates a Prove() proofSet/helper pair walks.
+func nodeKey(level int, index int64) []byte {
+	key := make([]byte, 9)
+	key[0] = byte(level)
+	binary.BigEndian.PutUint64(key[1:], uint64(index))
+	return key
+}
+
+// NewSparseMerkleTree builds an empty tree of the given depth (2^depth
+// leaves, all initially zero) over store.
+func NewSparseMerkleTree(depth int, store KVStore) (*SparseMerkleTree, error) {
+	if depth <= 0 {
+		return nil, fmt.Errorf("tree: depth must be positive, got %d", depth)
+	}
+	zeroHashes := make([][]byte, depth+1)
+	zeroHashes[0] = make([]byte, 32)
+	for i := 1; i <= depth; i++ {
+		zeroHashes[i] = types.UpdateMerkleProofNative(zeroHashes[i-1], [][]byte{zeroHashes[i-1]}, []int{0})
+	}
+	return &SparseMerkleTree{
+		depth:      depth,
+		store:      store,
+		zeroHashes: zeroHashes,
+		root:       zeroHashes[depth],
+	}, nil
+}
+
+func (t *SparseMerkleTree) checkIndex(index int64) error {
+	if index < 0 || index >= int64(1)<<uint(t.depth) {
+		return fmt.Errorf("tree: index %d out of range for depth %d", index, t.depth)
+	}
+	return nil
+}
+
+// nodeAt returns the node at (level, index), or that level's empty subtree
+// hash if it was never written.
+func (t *SparseMerkleTree) nodeAt(level int, index int64) ([]byte, error) {
+	value, err := t.store.Get(nodeKey(level, index))
+	if err == ErrNotFound {
+		return t.zeroHashes[level], nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Get returns the leaf value at index, or the zero leaf if it was never Set.
+func (t *SparseMerkleTree) Get(index int64) ([]byte, error) {
+	if err := t.checkIndex(index); err != nil {
+		return nil, err
+	}
+	return t.nodeAt(0, index)
+}
+
+// Set writes leaf at index and recomputes every ancestor up to the root,
+// persisting each one so Get/Prove for sibling leaves stay correct.
+func (t *SparseMerkleTree) Set(index int64, leaf []byte) error {
+	if err := t.checkIndex(index); err != nil {
+		return err
+	}
+	if err := t.store.Put(nodeKey(0, index), leaf); err != nil {
+		return err
+	}
+
+	node := leaf
+	nodeIndex := index
+	for level := 0; level < t.depth; level++ {
+		sibling, err := t.nodeAt(level, nodeIndex^1)
+		if err != nil {
+			return err
+		}
+		left, right := node, sibling
+		if nodeIndex%2 != 0 {
+			left, right = sibling, node
+		}
+		node = types.UpdateMerkleProofNative(left, [][]byte{right}, []int{0})
+		nodeIndex /= 2
+		if err := t.store.Put(nodeKey(level+1, nodeIndex), node); err != nil {
+			return err
+		}
+	}
+	t.root = node
+	return nil
+}
+
+// Root returns the tree's current root.
+func (t *SparseMerkleTree) Root() []byte {
+	return t.root
+}
+
+// Prove returns the proofSet/helper pair for index in exactly the format
+// types.UpdateMerkleProof/VerifyMerkleProof (and their native twins) take:
+// one sibling per level, leaf to root, with helper built by
+// types.MerkleHelperFromIndex.
+func (t *SparseMerkleTree) Prove(index int64) (proofSet [][]byte, helper []int, err error) {
+	if err = t.checkIndex(index); err != nil {
+		return nil, nil, err
+	}
+	helper = types.MerkleHelperFromIndex(index, t.depth)
+	proofSet = make([][]byte, t.depth)
+	nodeIndex := index
+	for level := 0; level < t.depth; level++ {
+		sibling, err := t.nodeAt(level, nodeIndex^1)
+		if err != nil {
+			return nil, nil, err
+		}
+		proofSet[level] = sibling
+		nodeIndex /= 2
+	}
+	return proofSet, helper, nil
+}
+
+// VerifyProof reports whether leaf, folded up through proofSet/helper via
+// types.UpdateMerkleProofNative, reaches root.
+func VerifyProof(root, leaf []byte, proofSet [][]byte, helper []int) bool {
+	computed := types.UpdateMerkleProofNative(leaf, proofSet, helper)
+	return new(big.Int).SetBytes(computed).Cmp(new(big.Int).SetBytes(root)) == 0
+}
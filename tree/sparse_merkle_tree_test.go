@@ -0,0 +1,84 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseMerkleTreeEmptyRootIsDeterministic(t *testing.T) {
+	first, err := NewSparseMerkleTree(4, NewMemoryStore())
+	require.NoError(t, err)
+	second, err := NewSparseMerkleTree(4, NewMemoryStore())
+	require.NoError(t, err)
+	require.Equal(t, first.Root(), second.Root())
+
+	_, err = NewSparseMerkleTree(0, NewMemoryStore())
+	require.Error(t, err)
+}
+
+func TestSparseMerkleTreeSetGetProveRoundTrip(t *testing.T) {
+	smt, err := NewSparseMerkleTree(4, NewMemoryStore())
+	require.NoError(t, err)
+	emptyRoot := smt.Root()
+
+	leaf := []byte("leaf-5")
+	require.NoError(t, smt.Set(5, leaf))
+	require.NotEqual(t, emptyRoot, smt.Root())
+
+	got, err := smt.Get(5)
+	require.NoError(t, err)
+	require.Equal(t, leaf, got)
+
+	// an untouched leaf still reads as the zero leaf.
+	other, err := smt.Get(6)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 32), other)
+
+	proofSet, helper, err := smt.Prove(5)
+	require.NoError(t, err)
+	require.Len(t, proofSet, 4)
+	require.True(t, VerifyProof(smt.Root(), leaf, proofSet, helper))
+	require.False(t, VerifyProof(smt.Root(), []byte("wrong-leaf"), proofSet, helper))
+
+	_, err = smt.Get(16)
+	require.Error(t, err)
+	_, _, err = smt.Prove(-1)
+	require.Error(t, err)
+	require.Error(t, smt.Set(16, leaf))
+}
+
+func TestSparseMerkleTreeUpdatingOneLeafDoesNotBreakAnothersProof(t *testing.T) {
+	smt, err := NewSparseMerkleTree(3, NewMemoryStore())
+	require.NoError(t, err)
+
+	require.NoError(t, smt.Set(1, []byte("leaf-1")))
+	proofSetBefore, helperBefore, err := smt.Prove(2)
+	require.NoError(t, err)
+	require.True(t, VerifyProof(smt.Root(), make([]byte, 32), proofSetBefore, helperBefore))
+
+	require.NoError(t, smt.Set(2, []byte("leaf-2")))
+	proofSetAfter, helperAfter, err := smt.Prove(2)
+	require.NoError(t, err)
+	require.True(t, VerifyProof(smt.Root(), []byte("leaf-2"), proofSetAfter, helperAfter))
+
+	// leaf-1's proof against the new root still verifies leaf-1.
+	proofSet1, helper1, err := smt.Prove(1)
+	require.NoError(t, err)
+	require.True(t, VerifyProof(smt.Root(), []byte("leaf-1"), proofSet1, helper1))
+}
+
+func TestProofToBigIntSlice(t *testing.T) {
+	smt, err := NewSparseMerkleTree(4, NewMemoryStore())
+	require.NoError(t, err)
+	require.NoError(t, smt.Set(0, []byte("leaf-0")))
+	proofSet, _, err := smt.Prove(0)
+	require.NoError(t, err)
+
+	bigInts, err := ProofToBigIntSlice(proofSet, 4)
+	require.NoError(t, err)
+	require.Len(t, bigInts, 4)
+
+	_, err = ProofToBigIntSlice(proofSet, 5)
+	require.Error(t, err)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package tree implements a MiMC-based sparse Merkle tree that produces
+// proofs in exactly the shape the circuit package verifies
+// (types.UpdateMerkleProofNative/types.MerkleHelperFromIndex, the native
+// twins of types.UpdateMerkleProof/VerifyMerkleProof), so a proof this
+// package builds is accepted by the circuit without translation.
+package tree
+
+import "errors"
+
+// ErrNotFound is returned by KVStore.Get when key isn't present. It
+// mirrors github.com/syndtr/goleveldb/leveldb.ErrNotFound so a LevelDB
+// backend and the in-memory one signal "missing" the same way.
+var ErrNotFound = errors.New("tree: key not found")
+
+// KVStore is the storage interface SparseMerkleTree persists nodes
+// through. Its method shapes match a plain get/put/delete/has key-value
+// store closely enough that wrapping a *leveldb.DB (or any other on-disk
+// store) is a few lines of glue; this module doesn't take on a LevelDB
+// dependency itself, since it's a shared crypto library rather than the
+// service that owns the tree's storage lifetime.
+type KVStore interface {
+	Get(key []byte) ([]byte, error)
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	Has(key []byte) (bool, error)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package tss lets several signers jointly authorize a tx under one L2
+// account, without the circuit having to know about it: VerifyTransaction
+// checks one eddsa signature against the account's single public key, so
+// any scheme that ends with a valid signature under that same key needs no
+// circuit change at all.
+//
+// This package gets there with Shamir secret sharing of the account's
+// signing scalar (see SplitPrivateKey/ReconstructScalar) rather than a
+// fully non-interactive threshold-signing protocol (e.g. FROST): threshold
+// shares briefly combine into the real private key in one signer's memory
+// at signing time. For an exchange's withdrawal approval flow - run on
+// infrastructure it already trusts, where the real goal is "no single
+// employee can sign alone" rather than defending against a compromised
+// signing host - that tradeoff buys a far simpler implementation. A
+// deployment that must never reconstruct the full key in one place needs
+// an interactive MPC signing protocol instead.
+package tss
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+)
+
+// Share is one signer's piece of a split eddsa signing scalar. Index is
+// the share's x-coordinate on the sharing polynomial (starting at 1;
+// never 0, which would leak the secret itself).
+type Share struct {
+	Index uint64
+	Value *big.Int
+}
+
+// SplitPrivateKey splits sk's signing scalar into n Shamir shares such
+// that any threshold of them - but no fewer - reconstruct the scalar
+// exactly via ReconstructScalar. sk itself is not retained by the caller
+// once split; SplitPrivateKey is meant to run once, at key-generation
+// time, after which only shares need to be kept.
+func SplitPrivateKey(sk *curve.PrivateKey, threshold, n int) ([]Share, error) {
+	if threshold < 1 || n < threshold {
+		return nil, errors.New("threshold must be between 1 and n")
+	}
+
+	scalar := new(big.Int).SetBytes(sk.Bytes()[32:64])
+
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = scalar
+	for i := 1; i < threshold; i++ {
+		coefficient, err := ffmath.RandomValue(curve.Order)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = coefficient
+	}
+
+	shares := make([]Share, n)
+	for i := 0; i < n; i++ {
+		x := big.NewInt(int64(i + 1))
+		shares[i] = Share{Index: uint64(i + 1), Value: evalPolynomial(coefficients, x)}
+	}
+	return shares, nil
+}
+
+// ReconstructScalar combines threshold-many shares via Lagrange
+// interpolation at x=0, recovering the signing scalar SplitPrivateKey
+// split. Any threshold-sized subset of the original shares reconstructs
+// the same scalar; fewer than threshold reveal nothing about it.
+func ReconstructScalar(shares []Share) (*big.Int, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+
+	result := big.NewInt(0)
+	for i, share := range shares {
+		xi := new(big.Int).SetUint64(share.Index)
+		numerator := big.NewInt(1)
+		denominator := big.NewInt(1)
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			xj := new(big.Int).SetUint64(other.Index)
+			numerator = ffmath.MultiplyMod(numerator, xj, curve.Order)
+			denominator = ffmath.MultiplyMod(denominator, ffmath.SubMod(xj, xi, curve.Order), curve.Order)
+		}
+		lagrangeCoefficient := ffmath.MultiplyMod(numerator, ffmath.ModInverse(denominator, curve.Order), curve.Order)
+		term := ffmath.MultiplyMod(share.Value, lagrangeCoefficient, curve.Order)
+		result = ffmath.AddMod(result, term, curve.Order)
+	}
+	return result, nil
+}
+
+// ReconstructPrivateKey combines threshold-many shares into the full
+// eddsa private key for pubKey, ready to Sign a tx hash with. It draws a
+// fresh random blinding source, so distinct reconstructions of the same
+// shares sign with distinct (but equally valid) nonces, matching ordinary
+// eddsa signing.
+func ReconstructPrivateKey(pubKey *curve.PublicKey, shares []Share) (*curve.PrivateKey, error) {
+	scalar, err := ReconstructScalar(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	scalarBytes := make([]byte, 32)
+	scalar.FillBytes(scalarBytes)
+
+	randSrc := make([]byte, 32)
+	if _, err := rand.Read(randSrc); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, 96)
+	buf = append(buf, pubKey.Bytes()...)
+	buf = append(buf, scalarBytes...)
+	buf = append(buf, randSrc...)
+
+	sk := new(curve.PrivateKey)
+	if _, err := sk.SetBytes(buf); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+func evalPolynomial(coefficients []*big.Int, x *big.Int) *big.Int {
+	result := big.NewInt(0)
+	xPower := big.NewInt(1)
+	for _, coefficient := range coefficients {
+		result = ffmath.AddMod(result, ffmath.MultiplyMod(coefficient, xPower, curve.Order), curve.Order)
+		xPower = ffmath.MultiplyMod(xPower, x, curve.Order)
+	}
+	return result
+}
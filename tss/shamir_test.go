@@ -0,0 +1,93 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tss
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func TestThresholdSignatureVerifiesAgainstOriginalPublicKey(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("tss test account seed")
+	require.NoError(t, err)
+
+	shares, err := SplitPrivateKey(sk, 2, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+
+	// Any 2-of-3 subset should reconstruct a key that signs exactly like
+	// the original, so the circuit's single-signer VerifySignature needs
+	// no change at all.
+	reconstructed, err := ReconstructPrivateKey(&sk.PublicKey, []Share{shares[0], shares[2]})
+	require.NoError(t, err)
+	require.Equal(t, sk.PublicKey.Bytes(), reconstructed.PublicKey.Bytes())
+
+	msg := []byte("withdraw 100 BNB")
+	hFunc := mimc.NewMiMC()
+	signature, err := reconstructed.Sign(msg, hFunc)
+	require.NoError(t, err)
+
+	hFunc.Reset()
+	isValid, err := sk.PublicKey.Verify(signature, msg, hFunc)
+	require.NoError(t, err)
+	require.True(t, isValid)
+}
+
+func TestReconstructPrivateKeyRejectsFewerThanThreshold(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("tss test account seed")
+	require.NoError(t, err)
+
+	shares, err := SplitPrivateKey(sk, 2, 3)
+	require.NoError(t, err)
+
+	// ReconstructPrivateKey trusts the caller's pubKey and doesn't
+	// validate the recovered scalar against it, so this only surfaces as
+	// a signature that fails verification against the real account key.
+	reconstructed, err := ReconstructPrivateKey(&sk.PublicKey, []Share{shares[0]})
+	require.NoError(t, err)
+
+	msg := []byte("withdraw 100 BNB")
+	hFunc := mimc.NewMiMC()
+	signature, err := reconstructed.Sign(msg, hFunc)
+	require.NoError(t, err)
+
+	hFunc.Reset()
+	isValid, err := sk.PublicKey.Verify(signature, msg, hFunc)
+	require.NoError(t, err)
+	require.False(t, isValid)
+}
+
+func TestSplitPrivateKeyRejectsInvalidThreshold(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("tss test account seed")
+	require.NoError(t, err)
+
+	_, err = SplitPrivateKey(sk, 0, 3)
+	require.Error(t, err)
+
+	_, err = SplitPrivateKey(sk, 4, 3)
+	require.Error(t, err)
+}
+
+func TestReconstructScalarRejectsNoShares(t *testing.T) {
+	_, err := ReconstructScalar(nil)
+	require.Error(t, err)
+}
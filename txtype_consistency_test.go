@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package zkbnbcrypto_test
+
+import (
+	"testing"
+
+	circuittypes "github.com/bnb-chain/zkbnb-crypto/circuit/types"
+	"github.com/bnb-chain/zkbnb-crypto/wasm/txtypes"
+)
+
+// TestTxTypeConstantsStayInLockstep guards the one invariant wasm/txtypes's
+// and circuit/types's independent TxType iota blocks must hold: every tx
+// type that exists in both is numerically identical, so pubdata and hashes
+// computed from one side's constant check out against the other. TxTypeOffer
+// is deliberately excluded - an Offer is only ever embedded inside an
+// AtomicMatch or CancelOffer, never submitted as an L2 tx by itself, so it
+// has no circuit/types counterpart.
+func TestTxTypeConstantsStayInLockstep(t *testing.T) {
+	shared := []struct {
+		name    string
+		wasm    int
+		circuit int
+	}{
+		{"Empty", txtypes.TxTypeEmpty, circuittypes.TxTypeEmptyTx},
+		{"RegisterZns", txtypes.TxTypeRegisterZns, circuittypes.TxTypeRegisterZns},
+		{"Deposit", txtypes.TxTypeDeposit, circuittypes.TxTypeDeposit},
+		{"DepositNft", txtypes.TxTypeDepositNft, circuittypes.TxTypeDepositNft},
+		{"Transfer", txtypes.TxTypeTransfer, circuittypes.TxTypeTransfer},
+		{"Withdraw", txtypes.TxTypeWithdraw, circuittypes.TxTypeWithdraw},
+		{"CreateCollection", txtypes.TxTypeCreateCollection, circuittypes.TxTypeCreateCollection},
+		{"MintNft", txtypes.TxTypeMintNft, circuittypes.TxTypeMintNft},
+		{"TransferNft", txtypes.TxTypeTransferNft, circuittypes.TxTypeTransferNft},
+		{"AtomicMatch", txtypes.TxTypeAtomicMatch, circuittypes.TxTypeAtomicMatch},
+		{"CancelOffer", txtypes.TxTypeCancelOffer, circuittypes.TxTypeCancelOffer},
+		{"WithdrawNft", txtypes.TxTypeWithdrawNft, circuittypes.TxTypeWithdrawNft},
+		{"FullExit", txtypes.TxTypeFullExit, circuittypes.TxTypeFullExit},
+		{"FullExitNft", txtypes.TxTypeFullExitNft, circuittypes.TxTypeFullExitNft},
+		{"ChangePubKey", txtypes.TxTypeChangePubKey, circuittypes.TxTypeChangePubKey},
+	}
+	for _, tt := range shared {
+		if tt.wasm != tt.circuit {
+			t.Errorf("%s: wasm/txtypes=%d, circuit/types=%d, want equal", tt.name, tt.wasm, tt.circuit)
+		}
+	}
+}
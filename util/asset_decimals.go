@@ -0,0 +1,60 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AssetDecimals maps an asset id to the number of decimal places separating
+// its base unit (the integer amount a tx actually carries) from its display
+// unit (e.g. 18 for an asset whose base unit is 10^-18 of one display unit,
+// the way wei relates to ether). Assets not present here have no registered
+// decimals, so ValidateBaseUnitScale has nothing to check their amounts
+// against and treats them as always plausible.
+var AssetDecimals = map[int64]int64{}
+
+// RegisterAssetDecimals sets assetId's decimals. decimals must not be
+// negative.
+func RegisterAssetDecimals(assetId, decimals int64) error {
+	if decimals < 0 {
+		return fmt.Errorf("decimals for asset %d should not be negative", assetId)
+	}
+	AssetDecimals[assetId] = decimals
+	return nil
+}
+
+func assetDecimalsFor(assetId int64) (int64, bool) {
+	decimals, ok := AssetDecimals[assetId]
+	return decimals, ok
+}
+
+// ValidateBaseUnitScale checks that displayAmount, a decimal string such as
+// "1.5" expressed in assetId's display unit, scales to exactly amount base
+// units once assetId's registered decimals are applied. This catches the
+// common integration bug of mixing up display and base units: passing a
+// display-unit amount where base units are expected lands many orders of
+// magnitude off, or leaves a fractional remainder that has no base-unit
+// representation at all. If assetId has no registered decimals,
+// ValidateBaseUnitScale is a no-op, since there is nothing to scale against.
+func ValidateBaseUnitScale(assetId int64, amount *big.Int, displayAmount string) error {
+	decimals, ok := assetDecimalsFor(assetId)
+	if !ok {
+		return nil
+	}
+	if amount == nil {
+		return fmt.Errorf("amount should not be nil")
+	}
+	display, ok := new(big.Rat).SetString(displayAmount)
+	if !ok {
+		return fmt.Errorf("displayAmount %q is not a valid decimal number", displayAmount)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
+	scaled := new(big.Rat).Mul(display, new(big.Rat).SetInt(scale))
+	if !scaled.IsInt() {
+		return fmt.Errorf("asset %d display amount %q has more precision than its %d registered decimals allow", assetId, displayAmount, decimals)
+	}
+	if scaled.Num().Cmp(amount) != 0 {
+		return fmt.Errorf("asset %d amount %s does not match display amount %q scaled to base units", assetId, amount.String(), displayAmount)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidateBaseUnitScale(t *testing.T) {
+	const assetWithDecimals = int64(31)
+	const unregisteredAsset = int64(32)
+
+	if err := RegisterAssetDecimals(assetWithDecimals, 6); err != nil {
+		t.Fatalf("RegisterAssetDecimals failed: %v", err)
+	}
+
+	// "1.5" at 6 decimals is 1500000 base units.
+	if err := ValidateBaseUnitScale(assetWithDecimals, big.NewInt(1500000), "1.5"); err != nil {
+		t.Fatalf("ValidateBaseUnitScale failed: %v", err)
+	}
+
+	// the display amount was left unscaled, as if base units were decimals
+	if err := ValidateBaseUnitScale(assetWithDecimals, big.NewInt(15), "1.5"); err == nil {
+		t.Fatalf("expected an error for an amount at the wrong scale")
+	}
+
+	// more fractional precision than 6 decimals can represent
+	if err := ValidateBaseUnitScale(assetWithDecimals, big.NewInt(1500000), "1.5000001"); err == nil {
+		t.Fatalf("expected an error for a display amount with too much precision")
+	}
+
+	// an unregistered asset has nothing to scale against
+	if err := ValidateBaseUnitScale(unregisteredAsset, big.NewInt(15), "1.5"); err != nil {
+		t.Fatalf("ValidateBaseUnitScale(unregisteredAsset) failed: %v", err)
+	}
+}
+
+func TestRegisterAssetDecimalsRejectsNegative(t *testing.T) {
+	if err := RegisterAssetDecimals(33, -1); err == nil {
+		t.Fatalf("expected an error for negative decimals")
+	}
+}
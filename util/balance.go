@@ -47,6 +47,27 @@ func ToPackedAmount(amount *big.Int) (res int64, err error) {
 	return packedAmount, nil
 }
 
+// MaxBalance returns the largest integer balance a packed asset amount can
+// represent: the maximum mantissa the packed field's bit width can hold
+// (PackedAmountMaxMantissa) scaled by the largest representable power-of-ten
+// exponent. It is exactly PackedAmountMaxAmount, exposed as a function so
+// callers can reason about it as "the range proof's ceiling" rather than a
+// bare constant.
+func MaxBalance() *big.Int {
+	return new(big.Int).Set(PackedAmountMaxAmount)
+}
+
+func init() {
+	// A balance that ToPackedAmount itself would reject can never reach the
+	// chain, since every on-chain amount is committed in this packed form
+	// (see circuit/types/pubdata_helper.go). If PackedAmountMaxAmount ever
+	// drifted out of sync with the mantissa/exponent bit widths it's built
+	// from, this catches it at program start rather than at proving time.
+	if _, err := ToPackedAmount(PackedAmountMaxAmount); err != nil {
+		panic("[util] PackedAmountMaxAmount exceeds what the packed-amount range proof can represent: " + err.Error())
+	}
+}
+
 func CleanPackedAmount(amount *big.Int) (nAmount *big.Int, err error) {
 	if amount.Cmp(ZeroBigInt) < 0 || amount.Cmp(PackedAmountMaxAmount) > 0 {
 		log.Println("[ToPackedAmount] invalid amount")
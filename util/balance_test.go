@@ -6,6 +6,15 @@ import (
 	"testing"
 )
 
+func TestMaxBalanceMatchesPackedAmountMaxAmount(t *testing.T) {
+	if MaxBalance().Cmp(PackedAmountMaxAmount) != 0 {
+		t.Fatalf("MaxBalance() = %s, want %s", MaxBalance(), PackedAmountMaxAmount)
+	}
+	if _, err := ToPackedAmount(MaxBalance()); err != nil {
+		t.Fatalf("MaxBalance() is not representable by the packed-amount range proof: %s", err)
+	}
+}
+
 func TestToPackedAmount(t *testing.T) {
 	a, _ := new(big.Int).SetString("343597383671", 10)
 	amount, err := ToPackedAmount(a)
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// CanonicalJSON marshals v the same way json.Marshal does, then rewrites the
+// result with object keys sorted and numbers kept exactly as they were
+// emitted, so two structurally-equal values produce byte-identical JSON
+// regardless of their Go struct's field declaration order. This is meant for
+// anywhere JSON output might end up hashed or compared across independent
+// implementations (e.g. a cross-language signer), where json.Marshal's
+// struct-field-order key ordering would otherwise make the same logical
+// value serialize differently depending on which language produced it. No
+// tx type here currently hashes JSON for its signature -- every signed
+// payload is a packed binary buffer instead (see e.g.
+// wasm/txtypes/transfer.go's ComputeHash) -- so CanonicalJSON has no call
+// site in this repo yet; it exists for if one of those payloads is ever
+// replaced with, or accompanied by, a JSON form.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, decoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, v interface{}) error {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			keyBytes, err := json.Marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(keyBytes)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, value[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		buf.WriteString(value.String())
+	case string:
+		strBytes, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(strBytes)
+	case bool:
+		if value {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("CanonicalJSON: unexpected decoded type %T", v)
+	}
+	return nil
+}
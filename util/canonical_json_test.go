@@ -0,0 +1,45 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestCanonicalJSONSortsKeysRegardlessOfFieldOrder(t *testing.T) {
+	type fieldsBFirst struct {
+		B int    `json:"b"`
+		A string `json:"a"`
+	}
+	type fieldsAFirst struct {
+		A string `json:"a"`
+		B int    `json:"b"`
+	}
+
+	out1, err := CanonicalJSON(fieldsBFirst{B: 2, A: "x"})
+	if err != nil {
+		t.Fatalf("CanonicalJSON failed: %v", err)
+	}
+	out2, err := CanonicalJSON(fieldsAFirst{A: "x", B: 2})
+	if err != nil {
+		t.Fatalf("CanonicalJSON failed: %v", err)
+	}
+
+	if string(out1) != string(out2) {
+		t.Fatalf("canonical JSON differed by field order: %q vs %q", out1, out2)
+	}
+	if string(out1) != `{"a":"x","b":2}` {
+		t.Fatalf("unexpected canonical JSON: %q", out1)
+	}
+}
+
+func TestCanonicalJSONSortsNestedMapKeys(t *testing.T) {
+	out, err := CanonicalJSON(map[string]interface{}{
+		"z": 1,
+		"a": map[string]interface{}{"y": 2, "x": 3},
+	})
+	if err != nil {
+		t.Fatalf("CanonicalJSON failed: %v", err)
+	}
+	if string(out) != `{"a":{"x":3,"y":2},"z":1}` {
+		t.Fatalf("unexpected canonical JSON: %q", out)
+	}
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// DustThreshold maps an asset id to the minimum amount of that asset a
+// transfer or swap may move. Assets not present here have no dust floor
+// (ValidateAboveDustThreshold treats a missing entry as the zero threshold,
+// i.e. disabled), matching how GasFeePrecision falls back to an unrestricted
+// default for an asset nobody has registered.
+var DustThreshold = map[int64]*big.Int{}
+
+// RegisterDustThreshold sets the minimum amount assetId may move in a
+// transfer or swap. threshold must not be negative.
+func RegisterDustThreshold(assetId int64, threshold *big.Int) error {
+	if threshold == nil || threshold.Sign() < 0 {
+		return fmt.Errorf("dust threshold for asset %d should not be negative", assetId)
+	}
+	DustThreshold[assetId] = threshold
+	return nil
+}
+
+func dustThresholdFor(assetId int64) *big.Int {
+	if threshold, ok := DustThreshold[assetId]; ok {
+		return threshold
+	}
+	return ZeroBigInt
+}
+
+// ValidateAboveDustThreshold rejects amount as economically irrelevant if it
+// falls below assetId's registered dust threshold. An asset with no
+// registered threshold allows any non-negative amount through unchanged.
+func ValidateAboveDustThreshold(assetId int64, amount *big.Int) error {
+	if amount == nil {
+		return fmt.Errorf("amount should not be nil")
+	}
+	if threshold := dustThresholdFor(assetId); amount.Cmp(threshold) < 0 {
+		return fmt.Errorf("asset %d amount is below its registered dust threshold", assetId)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidateAboveDustThreshold(t *testing.T) {
+	const assetWithThreshold = int64(11)
+	const unregisteredAsset = int64(12)
+
+	if err := RegisterDustThreshold(assetWithThreshold, big.NewInt(1000)); err != nil {
+		t.Fatalf("RegisterDustThreshold failed: %v", err)
+	}
+
+	if err := ValidateAboveDustThreshold(assetWithThreshold, big.NewInt(999)); err == nil {
+		t.Fatalf("expected an error for an amount below the registered dust threshold")
+	}
+	if err := ValidateAboveDustThreshold(assetWithThreshold, big.NewInt(1000)); err != nil {
+		t.Fatalf("ValidateAboveDustThreshold at the threshold failed: %v", err)
+	}
+
+	// An unregistered asset has no dust floor.
+	if err := ValidateAboveDustThreshold(unregisteredAsset, big.NewInt(0)); err != nil {
+		t.Fatalf("ValidateAboveDustThreshold(unregisteredAsset) failed: %v", err)
+	}
+}
+
+func TestRegisterDustThresholdRejectsNegative(t *testing.T) {
+	if err := RegisterDustThreshold(13, big.NewInt(-1)); err == nil {
+		t.Fatalf("expected an error for a negative dust threshold")
+	}
+}
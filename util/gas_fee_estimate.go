@@ -0,0 +1,24 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// EstimateGasFee looks up txType's fee in feeSchedule and rounds it down to
+// the value PackedFee would actually encode, the same rounding
+// ToPackedFeeWithPrecision/CleanPackedFee apply before a fee is committed to
+// a tx's msg hash. A wallet estimating a fee off of feeSchedule should use
+// this rounded-down value rather than the raw schedule entry, since that is
+// the amount the tx will actually be built and signed with.
+func EstimateGasFee(txType uint8, feeSchedule map[uint8]*big.Int) (*big.Int, error) {
+	fee, ok := feeSchedule[txType]
+	if !ok || fee == nil {
+		return nil, fmt.Errorf("no gas fee registered for tx type %d", txType)
+	}
+	packableFee, err := CleanPackedFee(fee)
+	if err != nil {
+		return nil, err
+	}
+	return packableFee, nil
+}
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+// tx type values mirror circuit/types.TxTypeTransfer/TxTypeWithdraw/
+// TxTypeMintNft; util does not import circuit/types, so they are repeated
+// here as plain uint8 literals.
+const (
+	transferTxType uint8 = 4
+	withdrawTxType uint8 = 5
+	mintNftTxType  uint8 = 7
+)
+
+func TestEstimateGasFeeAcrossTxTypes(t *testing.T) {
+	feeSchedule := map[uint8]*big.Int{
+		transferTxType: big.NewInt(12345),
+		withdrawTxType: big.NewInt(1000),
+	}
+
+	transferFee, err := EstimateGasFee(transferTxType, feeSchedule)
+	if err != nil {
+		t.Fatalf("EstimateGasFee(transfer) failed: %v", err)
+	}
+	wantTransferFee, err := CleanPackedFee(big.NewInt(12345))
+	if err != nil {
+		t.Fatalf("CleanPackedFee failed: %v", err)
+	}
+	if transferFee.Cmp(wantTransferFee) != 0 {
+		t.Fatalf("EstimateGasFee(transfer) = %s, want %s", transferFee, wantTransferFee)
+	}
+
+	withdrawFee, err := EstimateGasFee(withdrawTxType, feeSchedule)
+	if err != nil {
+		t.Fatalf("EstimateGasFee(withdraw) failed: %v", err)
+	}
+	if withdrawFee.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("EstimateGasFee(withdraw) = %s, want 1000 (already exactly packable)", withdrawFee)
+	}
+
+	if _, err := EstimateGasFee(mintNftTxType, feeSchedule); err == nil {
+		t.Fatalf("expected an error for a tx type missing from feeSchedule")
+	}
+
+	if _, err := EstimateGasFee(transferTxType, map[uint8]*big.Int{transferTxType: big.NewInt(-1)}); err == nil {
+		t.Fatalf("expected an error for a fee outside the packed range")
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/zkbnb-crypto/ffmath"
+)
+
+// DefaultGasFeePrecisionExponent is the coarsest rounding PackedFee allows:
+// an 11-bit mantissa scaled by 10^exponent, exponent up to 31. Assets not
+// present in GasFeePrecision are packed with this default, matching
+// ToPackedFee/CleanPackedFee's existing behavior.
+const DefaultGasFeePrecisionExponent = 31
+
+// GasFeePrecision maps an asset id to the maximum power-of-ten exponent a
+// gas fee for that asset may be rounded to when packed. High-value assets
+// register a smaller exponent here so ToPackedFeeWithPrecision rejects fees
+// that would otherwise silently lose more precision than the asset allows.
+// The registered precision isn't a separate field threaded through the msg
+// hash: it gates the value before packing, so the hash still only ever
+// commits to the single PackedFee result, already rounded to within the
+// asset's allowed precision.
+var GasFeePrecision = map[int64]int64{}
+
+// RegisterGasFeePrecision sets the maximum rounding exponent PackedFee may
+// use for assetId.
+func RegisterGasFeePrecision(assetId, maxExponent int64) error {
+	if maxExponent < 0 || maxExponent > DefaultGasFeePrecisionExponent {
+		return fmt.Errorf("gas fee precision exponent %d out of range [0, %d]", maxExponent, DefaultGasFeePrecisionExponent)
+	}
+	GasFeePrecision[assetId] = maxExponent
+	return nil
+}
+
+func gasFeePrecisionFor(assetId int64) int64 {
+	if maxExponent, ok := GasFeePrecision[assetId]; ok {
+		return maxExponent
+	}
+	return DefaultGasFeePrecisionExponent
+}
+
+// ToPackedFeeWithPrecision is the asset-aware counterpart of ToPackedFee: it
+// packs amount the same way, but first rejects amounts that would need to be
+// rounded to a coarser exponent than assetId's registered precision allows.
+func ToPackedFeeWithPrecision(assetId int64, amount *big.Int) (res int64, err error) {
+	exponent, err := packedFeeExponent(amount)
+	if err != nil {
+		return 0, err
+	}
+	maxExponent := gasFeePrecisionFor(assetId)
+	if exponent > maxExponent {
+		return 0, fmt.Errorf("asset %d fee requires a rounding exponent of %d, exceeding its registered precision of %d", assetId, exponent, maxExponent)
+	}
+	return ToPackedFee(amount)
+}
+
+func packedFeeExponent(amount *big.Int) (int64, error) {
+	if amount.Cmp(ZeroBigInt) < 0 || amount.Cmp(PackedFeeMaxAmount) > 0 {
+		return 0, errors.New("[packedFeeExponent] invalid amount")
+	}
+	oAmount := new(big.Int).Set(amount)
+	exponent := int64(0)
+	for oAmount.Cmp(PackedFeeMaxMantissa) > 0 {
+		oAmount = ffmath.Div(oAmount, big.NewInt(10))
+		exponent++
+	}
+	return exponent, nil
+}
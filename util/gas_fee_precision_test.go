@@ -0,0 +1,52 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestToPackedFeeWithPrecision(t *testing.T) {
+	const highValueAsset = int64(1)
+	const ordinaryAsset = int64(2)
+
+	if err := RegisterGasFeePrecision(highValueAsset, 0); err != nil {
+		t.Fatalf("RegisterGasFeePrecision(highValueAsset) failed: %v", err)
+	}
+	if err := RegisterGasFeePrecision(ordinaryAsset, DefaultGasFeePrecisionExponent); err != nil {
+		t.Fatalf("RegisterGasFeePrecision(ordinaryAsset) failed: %v", err)
+	}
+
+	// 2048 needs one round of /10 to fit PackedFeeMaxMantissa (2047), i.e. exponent 1.
+	amount := big.NewInt(2048)
+
+	if _, err := ToPackedFeeWithPrecision(highValueAsset, amount); err == nil {
+		t.Fatalf("expected an error packing a rounded fee for the high-value asset, got none")
+	}
+
+	res, err := ToPackedFeeWithPrecision(ordinaryAsset, amount)
+	if err != nil {
+		t.Fatalf("ToPackedFeeWithPrecision(ordinaryAsset) failed: %v", err)
+	}
+	want, err := ToPackedFee(amount)
+	if err != nil {
+		t.Fatalf("ToPackedFee failed: %v", err)
+	}
+	if res != want {
+		t.Fatalf("ToPackedFeeWithPrecision(ordinaryAsset) = %d, want %d", res, want)
+	}
+
+	// An unregistered asset falls back to the current, unrestricted behavior.
+	const unregisteredAsset = int64(3)
+	if _, err := ToPackedFeeWithPrecision(unregisteredAsset, amount); err != nil {
+		t.Fatalf("ToPackedFeeWithPrecision(unregisteredAsset) failed: %v", err)
+	}
+}
+
+func TestRegisterGasFeePrecisionRejectsOutOfRangeExponent(t *testing.T) {
+	if err := RegisterGasFeePrecision(4, -1); err == nil {
+		t.Fatalf("expected an error for a negative exponent")
+	}
+	if err := RegisterGasFeePrecision(4, DefaultGasFeePrecisionExponent+1); err == nil {
+		t.Fatalf("expected an error for an exponent above the default")
+	}
+}
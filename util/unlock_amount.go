@@ -0,0 +1,23 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ValidateUnlockAmount checks that unlockAmount does not exceed lockedAmount,
+// the bound an unlock must satisfy against whatever balance was previously
+// locked. This repo does not have a lock/unlock tx type or a
+// LockedAssetInfo struct to hang this check off of (neither wasm/txtypes nor
+// circuit/types defines a lock or unlock tx); this is a standalone
+// utility recording the invariant such a feature would need if one is ever
+// added. The in-circuit counterpart is types.AssertUnlockWithinLocked.
+func ValidateUnlockAmount(unlockAmount, lockedAmount *big.Int) error {
+	if unlockAmount == nil || lockedAmount == nil {
+		return fmt.Errorf("unlockAmount and lockedAmount should not be nil")
+	}
+	if unlockAmount.Cmp(lockedAmount) > 0 {
+		return fmt.Errorf("unlock amount %s exceeds locked amount %s", unlockAmount.String(), lockedAmount.String())
+	}
+	return nil
+}
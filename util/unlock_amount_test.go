@@ -0,0 +1,18 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidateUnlockAmountRejectsOverUnlock(t *testing.T) {
+	if err := ValidateUnlockAmount(big.NewInt(101), big.NewInt(100)); err == nil {
+		t.Fatalf("expected an error for an unlock amount exceeding the locked amount")
+	}
+	if err := ValidateUnlockAmount(big.NewInt(100), big.NewInt(100)); err != nil {
+		t.Fatalf("ValidateUnlockAmount at the locked amount failed: %v", err)
+	}
+	if err := ValidateUnlockAmount(big.NewInt(50), big.NewInt(100)); err != nil {
+		t.Fatalf("ValidateUnlockAmount under the locked amount failed: %v", err)
+	}
+}
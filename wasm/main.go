@@ -56,5 +56,7 @@ func main() {
 	js.Global().Set("signMintNft", src2.MintNftTx())
 	js.Global().Set("signTransferNft", src2.TransferNftTx())
 	js.Global().Set("signWithdrawNft", src2.WithdrawNftTx())
+	js.Global().Set("signChangePubKey", src2.ChangePubKeyTx())
+	js.Global().Set("getChangePubKeyStructHash", src2.ChangePubKeyStructHash())
 	<-make(chan bool)
 }
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package src
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log"
+
+	"syscall/js"
+
+	"github.com/bnb-chain/zkbnb-crypto/wasm/txtypes"
+)
+
+// ChangePubKeyTx builds a ChangePubKeyTxInfo from segmentStr. Unlike the
+// other Xxx Tx helpers, it takes no seed: a ChangePubKey isn't signed with
+// the account's eddsa key (that's the key being replaced), so there's
+// nothing to sign here. Call ChangePubKeyStructHash with the same segmentStr
+// to get the digest an L1 wallet signs for AuthModeECDSA, or leave
+// EthSignature unset for AuthModeL1PriorityOp.
+func ChangePubKeyTx() js.Func {
+	helperFunc := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) != 1 {
+			return "invalid change pub key params"
+		}
+		segmentStr := args[0].String()
+		txInfo, err := txtypes.ConstructChangePubKeyTxInfo(segmentStr)
+		if err != nil {
+			log.Println("[ChangePubKeyTx] unable to construct change pub key tx:", err)
+			return err.Error()
+		}
+		txInfoBytes, err := json.Marshal(txInfo)
+		if err != nil {
+			log.Println("[ChangePubKeyTx] unable to marshal:", err)
+			return err.Error()
+		}
+		return string(txInfoBytes)
+	})
+	return helperFunc
+}
+
+// ChangePubKeyStructHash returns the hex-encoded EIP-712 digest of the
+// ChangePubKeyTxInfo built from segmentStr (the same segment JSON
+// ChangePubKeyTx takes), ready for an L1 wallet (e.g. MetaMask) to sign as
+// that tx's EthSignature.
+func ChangePubKeyStructHash() js.Func {
+	helperFunc := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) != 1 {
+			return "invalid change pub key params"
+		}
+		segmentStr := args[0].String()
+		txInfo, err := txtypes.ConstructChangePubKeyTxInfo(segmentStr)
+		if err != nil {
+			log.Println("[ChangePubKeyStructHash] unable to construct change pub key tx:", err)
+			return err.Error()
+		}
+		digest, err := txtypes.EIP712StructHash(txInfo)
+		if err != nil {
+			log.Println("[ChangePubKeyStructHash] unable to compute struct hash:", err)
+			return err.Error()
+		}
+		return hex.EncodeToString(digest)
+	})
+	return helperFunc
+}
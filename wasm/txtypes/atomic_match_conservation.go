@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ValidateAtomicMatchConservation checks that an atomic match cannot pay the
+// creator and the matcher more than the buyer actually hands over: the
+// creator royalty and the matcher's (treasury) fee, plus the submitter's gas
+// fee when it is paid in the same asset as the sale, must not together
+// exceed BuyOffer.AssetAmount. The remainder is the seller's proceeds, which
+// this package does not carry as its own field. Gas is only folded in when
+// it is paid in the same asset as the sale.
+func ValidateAtomicMatchConservation(match *AtomicMatchTxInfo) error {
+	if match == nil || match.BuyOffer == nil || match.SellOffer == nil {
+		return errors.New("match, BuyOffer and SellOffer should not be nil")
+	}
+	if match.BuyOffer.AssetAmount == nil || match.SellOffer.AssetAmount == nil {
+		return errors.New("BuyOffer and SellOffer AssetAmount should not be nil")
+	}
+	if match.BuyOffer.AssetAmount.Cmp(match.SellOffer.AssetAmount) != 0 {
+		return errors.New("BuyOffer and SellOffer AssetAmount should match")
+	}
+	if match.CreatorAmount == nil || match.CreatorAmount.Sign() < 0 {
+		return errors.New("CreatorAmount should not be negative")
+	}
+	if match.TreasuryAmount == nil || match.TreasuryAmount.Sign() < 0 {
+		return errors.New("TreasuryAmount should not be negative")
+	}
+
+	takenFromPayment := new(big.Int).Add(match.CreatorAmount, match.TreasuryAmount)
+	if match.GasFeeAssetAmount != nil && match.GasFeeAssetId == match.BuyOffer.AssetId {
+		takenFromPayment.Add(takenFromPayment, match.GasFeeAssetAmount)
+	}
+	if takenFromPayment.Cmp(match.BuyOffer.AssetAmount) > 0 {
+		return errors.New("CreatorAmount, TreasuryAmount and gas fee exceed the buyer's payment")
+	}
+	return nil
+}
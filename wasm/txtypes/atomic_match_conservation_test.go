@@ -0,0 +1,68 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validAtomicMatch() *AtomicMatchTxInfo {
+	return &AtomicMatchTxInfo{
+		BuyOffer:          &OfferTxInfo{AssetId: 0, AssetAmount: big.NewInt(100)},
+		SellOffer:         &OfferTxInfo{AssetId: 0, AssetAmount: big.NewInt(100)},
+		CreatorAmount:     big.NewInt(5),
+		TreasuryAmount:    big.NewInt(3),
+		GasFeeAssetId:     1,
+		GasFeeAssetAmount: big.NewInt(2),
+	}
+}
+
+func TestValidateAtomicMatchConservation(t *testing.T) {
+	// creator royalty and matcher fee leave room for the seller's proceeds
+	require.NoError(t, ValidateAtomicMatchConservation(validAtomicMatch()))
+
+	// gas paid in a different asset than the sale does not count against
+	// the buyer's payment
+	gasDifferentAsset := validAtomicMatch()
+	gasDifferentAsset.CreatorAmount = big.NewInt(50)
+	gasDifferentAsset.TreasuryAmount = big.NewInt(50)
+	require.NoError(t, ValidateAtomicMatchConservation(gasDifferentAsset))
+
+	// gas paid in the same asset as the sale is folded into the amount
+	// taken from the buyer's payment
+	gasSameAsset := validAtomicMatch()
+	gasSameAsset.GasFeeAssetId = gasSameAsset.BuyOffer.AssetId
+	gasSameAsset.CreatorAmount = big.NewInt(50)
+	gasSameAsset.TreasuryAmount = big.NewInt(49)
+	require.Error(t, ValidateAtomicMatchConservation(gasSameAsset))
+
+	// an imbalanced match: creator royalty plus matcher fee alone exceed
+	// the buyer's payment
+	imbalanced := validAtomicMatch()
+	imbalanced.CreatorAmount = big.NewInt(60)
+	imbalanced.TreasuryAmount = big.NewInt(60)
+	require.Error(t, ValidateAtomicMatchConservation(imbalanced))
+
+	// buy and sell offers disagree on price
+	mismatched := validAtomicMatch()
+	mismatched.SellOffer.AssetAmount = big.NewInt(90)
+	require.Error(t, ValidateAtomicMatchConservation(mismatched))
+}
@@ -138,6 +138,11 @@ func (txInfo *CancelOfferTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	return nil
 }
 
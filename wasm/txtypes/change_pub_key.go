@@ -0,0 +1,227 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AuthMode values for ChangePubKeyTxInfo.AuthMode: a key rotation is
+// authorized either by an ECDSA signature from the account's L1 owner, or by
+// a priority operation the L1 contract already queued (the same trust model
+// RegisterZnsTxInfo uses for account creation).
+const (
+	AuthModeECDSA = iota
+	AuthModeL1PriorityOp
+)
+
+type ChangePubKeySegmentFormat struct {
+	AccountIndex      int64  `json:"account_index"`
+	NewPubKey         string `json:"new_pub_key"`
+	GasAccountIndex   int64  `json:"gas_account_index"`
+	GasFeeAssetId     int64  `json:"gas_fee_asset_id"`
+	GasFeeAssetAmount string `json:"gas_fee_asset_amount"`
+	AuthMode          uint8  `json:"auth_mode"`
+	L1Address         string `json:"l1_address"`
+	ExpiredAt         int64  `json:"expired_at"`
+	Nonce             int64  `json:"nonce"`
+}
+
+// ConstructChangePubKeyTxInfo parses segmentStr into a ChangePubKeyTxInfo.
+// Unlike the eddsa-signed tx types, it takes no PrivateKey: a ChangePubKey is
+// authorized either by an ECDSA signature collected from the caller's L1
+// wallet (set EthSignature to VerifyEIP712Signature's expected 65-byte
+// signature over EIP712StructHash(txInfo) once this returns) or, for
+// AuthModeL1PriorityOp, by an L1 priority operation the caller doesn't sign
+// at all.
+func ConstructChangePubKeyTxInfo(segmentStr string) (txInfo *ChangePubKeyTxInfo, err error) {
+	var segmentFormat *ChangePubKeySegmentFormat
+	err = json.Unmarshal([]byte(segmentStr), &segmentFormat)
+	if err != nil {
+		log.Println("[ConstructChangePubKeyTxInfo] err info:", err)
+		return nil, err
+	}
+	gasFeeAmount, err := StringToBigInt(segmentFormat.GasFeeAssetAmount)
+	if err != nil {
+		log.Println("[ConstructChangePubKeyTxInfo] unable to convert string to big int:", err)
+		return nil, err
+	}
+	gasFeeAmount, _ = CleanPackedFee(gasFeeAmount)
+	txInfo = &ChangePubKeyTxInfo{
+		AccountIndex:      segmentFormat.AccountIndex,
+		NewPubKey:         segmentFormat.NewPubKey,
+		GasAccountIndex:   segmentFormat.GasAccountIndex,
+		GasFeeAssetId:     segmentFormat.GasFeeAssetId,
+		GasFeeAssetAmount: gasFeeAmount,
+		AuthMode:          segmentFormat.AuthMode,
+		L1Address:         segmentFormat.L1Address,
+		ExpiredAt:         segmentFormat.ExpiredAt,
+		Nonce:             segmentFormat.Nonce,
+	}
+	return txInfo, nil
+}
+
+type ChangePubKeyTxInfo struct {
+	AccountIndex      int64
+	NewPubKey         string
+	GasAccountIndex   int64
+	GasFeeAssetId     int64
+	GasFeeAssetAmount *big.Int
+	AuthMode          uint8
+	L1Address         string
+	EthSignature      []byte
+	ExpiredAt         int64
+	Nonce             int64
+}
+
+func (txInfo *ChangePubKeyTxInfo) GetTxType() int {
+	return TxTypeChangePubKey
+}
+
+func (txInfo *ChangePubKeyTxInfo) Validate() error {
+	if txInfo.AccountIndex < minAccountIndex {
+		return ErrAccountIndexTooLow
+	}
+	if txInfo.AccountIndex > maxAccountIndex {
+		return ErrAccountIndexTooHigh
+	}
+
+	if _, err := ParsePublicKey(txInfo.NewPubKey); err != nil {
+		return ErrPubKeyInvalid
+	}
+
+	if txInfo.GasAccountIndex < minAccountIndex {
+		return ErrGasAccountIndexTooLow
+	}
+	if txInfo.GasAccountIndex > maxAccountIndex {
+		return ErrGasAccountIndexTooHigh
+	}
+	if err := ValidateGasAccountDiffersFromAccount(txInfo.AccountIndex, txInfo.GasAccountIndex); err != nil {
+		return err
+	}
+
+	if txInfo.GasFeeAssetId < minAssetId {
+		return ErrGasFeeAssetIdTooLow
+	}
+	if txInfo.GasFeeAssetId > maxAssetId {
+		return ErrGasFeeAssetIdTooHigh
+	}
+
+	if txInfo.GasFeeAssetAmount == nil {
+		return ErrGasFeeAssetAmountTooLow
+	}
+	if txInfo.GasFeeAssetAmount.Cmp(minPackedFeeAmount) < 0 {
+		return ErrGasFeeAssetAmountTooLow
+	}
+	if txInfo.GasFeeAssetAmount.Cmp(maxPackedFeeAmount) > 0 {
+		return ErrGasFeeAssetAmountTooHigh
+	}
+
+	if txInfo.Nonce < minNonce {
+		return ErrNonceTooLow
+	}
+
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
+	switch txInfo.AuthMode {
+	case AuthModeECDSA:
+		if !IsValidL1Address(txInfo.L1Address) {
+			return ErrL1AddressInvalid
+		}
+		if len(txInfo.EthSignature) != 65 {
+			return ErrEthSignatureInvalid
+		}
+	case AuthModeL1PriorityOp:
+		// Trusted from an L1 priority operation, the same way
+		// RegisterZnsTxInfo trusts its fields: no signature to check here.
+	default:
+		return ErrAuthModeInvalid
+	}
+
+	return nil
+}
+
+// VerifySignature is a no-op: a ChangePubKey isn't authorized by the
+// account's own eddsa key (that's the key being rotated), so there's nothing
+// for the generic eddsa signature check to verify against. Authorization is
+// checked separately by VerifyChangePubKeyAuthorization.
+func (txInfo *ChangePubKeyTxInfo) VerifySignature(pubKey string) error {
+	return nil
+}
+
+func (txInfo *ChangePubKeyTxInfo) GetFromAccountIndex() int64 {
+	return txInfo.AccountIndex
+}
+
+func (txInfo *ChangePubKeyTxInfo) GetNonce() int64 {
+	return txInfo.Nonce
+}
+
+func (txInfo *ChangePubKeyTxInfo) GetExpiredAt() int64 {
+	return txInfo.ExpiredAt
+}
+
+func (txInfo *ChangePubKeyTxInfo) Hash(hFunc hash.Hash) (msgHash []byte, err error) {
+	hFunc.Reset()
+	var buf bytes.Buffer
+	packedFee, err := ToPackedFee(txInfo.GasFeeAssetAmount)
+	if err != nil {
+		log.Println("[ChangePubKeyTxInfo.Hash] unable to pack fee amount: ", err.Error())
+		return nil, err
+	}
+	newPubKeyBytes, err := hex.DecodeString(txInfo.NewPubKey)
+	if err != nil {
+		log.Println("[ChangePubKeyTxInfo.Hash] invalid NewPubKey: ", err.Error())
+		return nil, err
+	}
+	WriteInt64IntoBuf(&buf, ChainId, txInfo.AccountIndex, txInfo.Nonce, txInfo.ExpiredAt)
+	WriteInt64IntoBuf(&buf, txInfo.GasAccountIndex, txInfo.GasFeeAssetId, packedFee)
+	WriteInt64IntoBuf(&buf, int64(txInfo.AuthMode))
+	buf.Write(newPubKeyBytes)
+	hFunc.Write(buf.Bytes())
+	msgHash = hFunc.Sum(nil)
+	return msgHash, nil
+}
+
+func (txInfo *ChangePubKeyTxInfo) GetGas() (int64, int64, *big.Int) {
+	return txInfo.GasAccountIndex, txInfo.GasFeeAssetId, txInfo.GasFeeAssetAmount
+}
+
+// VerifyChangePubKeyAuthorization checks that tx is actually authorized to
+// rotate its account's key, dispatching on AuthMode rather than going
+// through the generic eddsa VerifySignature path (see its doc comment).
+func VerifyChangePubKeyAuthorization(tx *ChangePubKeyTxInfo) error {
+	switch tx.AuthMode {
+	case AuthModeL1PriorityOp:
+		// Already authorized on L1; nothing left to check off-chain.
+		return nil
+	case AuthModeECDSA:
+		return VerifyEIP712Signature(tx, common.HexToAddress(tx.L1Address), tx.EthSignature)
+	default:
+		return ErrAuthModeInvalid
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func samplePubKeyHex(t *testing.T, seed string) string {
+	t.Helper()
+	sk, err := curve.GenerateEddsaPrivateKey(seed)
+	require.NoError(t, err)
+	return hex.EncodeToString(sk.PublicKey.Bytes())
+}
+
+func sampleChangePubKeyTx(t *testing.T, authMode uint8) *ChangePubKeyTxInfo {
+	t.Helper()
+	return &ChangePubKeyTxInfo{
+		AccountIndex:      1,
+		NewPubKey:         samplePubKeyHex(t, "change-pub-key-seed"),
+		GasAccountIndex:   2,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+		AuthMode:          authMode,
+		L1Address:         "0x1111111111111111111111111111111111111111",
+		ExpiredAt:         1_700_000_000_000,
+		Nonce:             1,
+	}
+}
+
+func TestValidateChangePubKeyTxInfo(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mutate  func(*ChangePubKeyTxInfo)
+		wantErr error
+	}{
+		{
+			name:    "account index too low",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.AccountIndex = minAccountIndex - 1 },
+			wantErr: ErrAccountIndexTooLow,
+		},
+		{
+			name:    "invalid new pub key",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.NewPubKey = "not-hex" },
+			wantErr: ErrPubKeyInvalid,
+		},
+		{
+			name:    "gas account same as account",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.GasAccountIndex = tx.AccountIndex },
+			wantErr: ErrGasAccountIndexSameAsAccountIndex,
+		},
+		{
+			name:    "invalid auth mode",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.AuthMode = 7 },
+			wantErr: ErrAuthModeInvalid,
+		},
+		{
+			name:    "ecdsa mode requires valid l1 address",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.L1Address = "not-an-address" },
+			wantErr: ErrL1AddressInvalid,
+		},
+		{
+			name:    "ecdsa mode requires a 65-byte signature",
+			mutate:  func(tx *ChangePubKeyTxInfo) { tx.EthSignature = []byte{1, 2, 3} },
+			wantErr: ErrEthSignatureInvalid,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := sampleChangePubKeyTx(t, AuthModeECDSA)
+			tx.EthSignature = make([]byte, 65)
+			tc.mutate(tx)
+			require.Equal(t, tc.wantErr, tx.Validate())
+		})
+	}
+}
+
+func TestValidateChangePubKeyTxInfoAcceptsL1PriorityOpWithoutSignature(t *testing.T) {
+	tx := sampleChangePubKeyTx(t, AuthModeL1PriorityOp)
+	require.NoError(t, tx.Validate())
+}
+
+func TestVerifyChangePubKeyAuthorizationL1PriorityOpIsTrusted(t *testing.T) {
+	tx := sampleChangePubKeyTx(t, AuthModeL1PriorityOp)
+	require.NoError(t, VerifyChangePubKeyAuthorization(tx))
+}
+
+func TestVerifyChangePubKeyAuthorizationECDSA(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := sampleChangePubKeyTx(t, AuthModeECDSA)
+	tx.L1Address = crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	digest, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	tx.EthSignature = sig
+
+	require.NoError(t, VerifyChangePubKeyAuthorization(tx))
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx.L1Address = crypto.PubkeyToAddress(other.PublicKey).Hex()
+	require.Error(t, VerifyChangePubKeyAuthorization(tx))
+}
+
+func TestChangePubKeyHashDiffersByNewPubKey(t *testing.T) {
+	tx := sampleChangePubKeyTx(t, AuthModeL1PriorityOp)
+	hash1, err := tx.Hash(mimc.NewMiMC())
+	require.NoError(t, err)
+
+	tx.NewPubKey = samplePubKeyHex(t, "a-different-seed")
+	hash2, err := tx.Hash(mimc.NewMiMC())
+	require.NoError(t, err)
+
+	require.NotEqual(t, hash1, hash2)
+}
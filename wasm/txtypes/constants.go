@@ -57,6 +57,12 @@ const (
 	TxTypeWithdrawNft
 	TxTypeFullExit
 	TxTypeFullExitNft
+	// TxTypeChangePubKey must stay numerically aligned with
+	// circuit/types.TxTypeChangePubKey; TxTypeOffer has no circuit
+	// counterpart (an Offer is only ever embedded inside an AtomicMatch or
+	// CancelOffer, never submitted as an L2 tx by itself), so it is the one
+	// type these two iota blocks don't share - keep it last.
+	TxTypeChangePubKey
 	TxTypeOffer
 )
 
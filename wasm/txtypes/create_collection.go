@@ -153,6 +153,11 @@ func (txInfo *CreateCollectionTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	return nil
 }
 
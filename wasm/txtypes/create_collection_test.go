@@ -165,6 +165,36 @@ func TestValidateCreateCollectionTxInfo(t *testing.T) {
 				Nonce:             1,
 			},
 		},
+		// Name at exactly the maximum length is still valid
+		{
+			nil,
+			&CreateCollectionTxInfo{
+				AccountIndex:      1,
+				CollectionId:      5,
+				Name:              strings.Repeat("t", maxCollectionNameLength),
+				Introduction:      "test introduction",
+				GasAccountIndex:   0,
+				GasFeeAssetId:     3,
+				GasFeeAssetAmount: big.NewInt(100),
+				ExpiredAt:         time.Now().Add(time.Hour).UnixMilli(),
+				Nonce:             1,
+			},
+		},
+		// Introduction at exactly the maximum length is still valid
+		{
+			nil,
+			&CreateCollectionTxInfo{
+				AccountIndex:      1,
+				CollectionId:      5,
+				Name:              "test name",
+				Introduction:      strings.Repeat("s", maxCollectionIntroductionLength),
+				GasAccountIndex:   0,
+				GasFeeAssetId:     3,
+				GasFeeAssetAmount: big.NewInt(100),
+				ExpiredAt:         time.Now().Add(time.Hour).UnixMilli(),
+				Nonce:             1,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
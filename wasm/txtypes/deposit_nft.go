@@ -33,6 +33,25 @@ func (txInfo *DepositNftTxInfo) Validate() error {
 	return nil
 }
 
+// ValidateDepositNftTxInfo checks that txInfo carries the L1 provenance a
+// deposit-nft must originate from: a real L1 contract address, a token id,
+// and a content hash. Validate() intentionally stays a no-op for this tx
+// type, since a deposit-nft is built from an already-confirmed L1 event
+// rather than signed by a wallet; this is a separate, opt-in check for
+// whoever constructs txInfo from that event.
+func ValidateDepositNftTxInfo(txInfo *DepositNftTxInfo) error {
+	if !IsValidL1Address(txInfo.NftL1Address) {
+		return ErrNftL1AddressInvalid
+	}
+	if txInfo.NftL1TokenId == nil || txInfo.NftL1TokenId.Sign() < 0 {
+		return ErrNftL1TokenIdInvalid
+	}
+	if !IsValidHashBytes(txInfo.NftContentHash) {
+		return ErrNftContentHashInvalid
+	}
+	return nil
+}
+
 func (txInfo *DepositNftTxInfo) VerifySignature(pubKey string) error {
 	return nil
 }
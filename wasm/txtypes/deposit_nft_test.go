@@ -0,0 +1,36 @@
+package txtypes
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validDepositNftTxInfo() *DepositNftTxInfo {
+	return &DepositNftTxInfo{
+		AccountNameHash: []byte{1, 2, 3},
+		NftL1Address:    "0x299d17c8b4e9967385dc9a3bb78f2a43f5a13bd0",
+		NftL1TokenId:    big.NewInt(11),
+		NftContentHash:  bytes.Repeat([]byte{1}, 32),
+		CollectionId:    5,
+		AccountIndex:    1,
+	}
+}
+
+func TestValidateDepositNftTxInfoValid(t *testing.T) {
+	require.NoError(t, ValidateDepositNftTxInfo(validDepositNftTxInfo()))
+}
+
+func TestValidateDepositNftTxInfoMissingL1Address(t *testing.T) {
+	txInfo := validDepositNftTxInfo()
+	txInfo.NftL1Address = ""
+	require.Equal(t, ErrNftL1AddressInvalid, ValidateDepositNftTxInfo(txInfo))
+}
+
+func TestValidateDepositNftTxInfoMissingTokenId(t *testing.T) {
+	txInfo := validDepositNftTxInfo()
+	txInfo.NftL1TokenId = nil
+	require.Equal(t, ErrNftL1TokenIdInvalid, ValidateDepositNftTxInfo(txInfo))
+}
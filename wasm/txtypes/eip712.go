@@ -0,0 +1,124 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/ethereum/go-ethereum/common"
+	gethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/bnb-chain/zkbnb-crypto/ecc/zp256"
+)
+
+// eip712Types declares the single EIP-712 message shape every tx type signs:
+// its own tx type tag, the account that's paying for it, the nonce and
+// expiry that are already part of every TxInfo, and txHash, the same
+// MiMC-based hash SigMessage/VerifySignature check against an eddsa
+// signature. Signing that hash (rather than re-deriving a per-tx-type
+// EIP-712 schema for Transfer, Withdraw, and so on) is what lets a MetaMask
+// signature stand in for an eddsa one without changing what's actually
+// being authorized.
+var eip712Types = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	},
+	"Tx": {
+		{Name: "txType", Type: "uint8"},
+		{Name: "accountIndex", Type: "int64"},
+		{Name: "nonce", Type: "int64"},
+		{Name: "expiredAt", Type: "int64"},
+		{Name: "txHash", Type: "bytes32"},
+	},
+}
+
+const eip712DomainName = "ZkBNB"
+const eip712DomainVersion = "1"
+
+// eip712TypedData builds the EIP-712 typed data for tx, ready to hash or to
+// hand to a wallet for display-and-sign.
+func eip712TypedData(tx TxInfo) (*apitypes.TypedData, error) {
+	txHash, err := tx.Hash(mimc.NewMiMC())
+	if err != nil {
+		return nil, err
+	}
+	return &apitypes.TypedData{
+		Types:       eip712Types,
+		PrimaryType: "Tx",
+		Domain: apitypes.TypedDataDomain{
+			Name:    eip712DomainName,
+			Version: eip712DomainVersion,
+			ChainId: gethmath.NewHexOrDecimal256(ChainId),
+		},
+		Message: apitypes.TypedDataMessage{
+			"txType":       gethmath.NewHexOrDecimal256(int64(tx.GetTxType())),
+			"accountIndex": gethmath.NewHexOrDecimal256(tx.GetFromAccountIndex()),
+			"nonce":        gethmath.NewHexOrDecimal256(tx.GetNonce()),
+			"expiredAt":    gethmath.NewHexOrDecimal256(tx.GetExpiredAt()),
+			"txHash":       common.BytesToHash(txHash).Bytes(),
+		},
+	}, nil
+}
+
+// EIP712StructHash returns the EIP-712 digest (keccak256("\x19\x01" ||
+// domainSeparator || hashStruct(message))) that a MetaMask user signs to
+// authorize tx, as an alternative to signing tx's native eddsa message hash
+// directly.
+func EIP712StructHash(tx TxInfo) ([]byte, error) {
+	typedData, err := eip712TypedData(tx)
+	if err != nil {
+		return nil, err
+	}
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256([]byte("\x19\x01"), domainSeparator, messageHash), nil
+}
+
+// VerifyEIP712Signature is the MetaMask-signature counterpart to
+// TxInfo.VerifySignature: instead of checking an eddsa signature against a
+// registered eddsa public key, it checks a 65-byte recoverable secp256k1
+// signature against ethAddress, the L1 address a user registered (or will
+// register) as that account's owner. It checks only the signature against
+// the ethAddress it is given and does not look up which address owns an
+// account; VerifyChangePubKeyAuthorization, for one, passes the
+// ChangePubKeyTxInfo's own L1Address.
+func VerifyEIP712Signature(tx TxInfo, ethAddress common.Address, signature []byte) error {
+	digest, err := EIP712StructHash(tx)
+	if err != nil {
+		return err
+	}
+	pubKey, err := zp256.RecoverPublicKey(digest, signature)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pubKey) != ethAddress {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTransferForEIP712() *TransferTxInfo {
+	return &TransferTxInfo{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		ToAccountNameHash: "0x01",
+		AssetId:           3,
+		AssetAmount:       big.NewInt(100),
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+		ExpiredAt:         1000,
+		Nonce:             1,
+	}
+}
+
+func TestVerifyEIP712SignatureAcceptsGenuineSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := sampleTransferForEIP712()
+
+	digest, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+
+	err = VerifyEIP712Signature(tx, crypto.PubkeyToAddress(key.PublicKey), sig)
+	require.NoError(t, err)
+}
+
+func TestVerifyEIP712SignatureRejectsWrongAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := sampleTransferForEIP712()
+
+	digest, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+
+	err = VerifyEIP712Signature(tx, crypto.PubkeyToAddress(other.PublicKey), sig)
+	require.Error(t, err)
+}
+
+func TestVerifyEIP712SignatureRejectsTamperedTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := sampleTransferForEIP712()
+
+	digest, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+
+	tx.AssetAmount = big.NewInt(999)
+	err = VerifyEIP712Signature(tx, crypto.PubkeyToAddress(key.PublicKey), sig)
+	require.Error(t, err)
+}
+
+func TestEIP712StructHashDiffersByTxHash(t *testing.T) {
+	tx := sampleTransferForEIP712()
+	hash1, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+
+	// AssetAmount is not an EIP-712 message field; it reaches the digest
+	// only through txHash.
+	tx.AssetAmount = big.NewInt(200)
+	hash2, err := EIP712StructHash(tx)
+	require.NoError(t, err)
+
+	require.NotEqual(t, hash1, hash2)
+}
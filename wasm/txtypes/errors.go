@@ -30,17 +30,29 @@ var (
 	ErrNftCollectionIdTooLow    = fmt.Errorf("NftCollectionId should not be less than %d", minCollectionId)
 	ErrNftCollectionIdTooHigh   = fmt.Errorf("NftCollectionId should not be larger than %d", maxCollectionId)
 	ErrCallDataHashInvalid      = fmt.Errorf("CallDataHash is invalid")
+	ErrNftL1AddressInvalid      = fmt.Errorf("NftL1Address is invalid")
+	ErrNftL1TokenIdInvalid      = fmt.Errorf("NftL1TokenId is invalid")
 
-	ErrCreatorAccountIndexTooLow  = fmt.Errorf("CreatorAccountIndex should not be less than %d", minAccountIndex)
-	ErrCreatorAccountIndexTooHigh = fmt.Errorf("CreatorAccountIndex should not be larger than %d", maxAccountIndex)
-	ErrToAccountIndexTooLow       = fmt.Errorf("ToAccountIndex should not be less than %d", minAccountIndex)
-	ErrToAccountIndexTooHigh      = fmt.Errorf("ToAccountIndex should not be larger than %d", maxAccountIndex)
-	ErrToAccountNameHashInvalid   = fmt.Errorf("ToAccountNameHash is invalid")
-	ErrCreatorTreasuryRateTooLow  = fmt.Errorf("CreatorTreasuryRate should  not be less than %d", minTreasuryRate)
-	ErrCreatorTreasuryRateTooHigh = fmt.Errorf("CreatorTreasuryRate should not be larger than %d", maxTreasuryRate)
-	ErrFromAccountIndexTooLow     = fmt.Errorf("FromAccountIndex should not be less than %d", minAccountIndex)
-	ErrFromAccountIndexTooHigh    = fmt.Errorf("FromAccountIndex should not be larger than %d", maxAccountIndex)
-	ErrToAddressInvalid           = fmt.Errorf("ToAddress is invalid")
-	ErrBuyOfferInvalid            = fmt.Errorf("BuyOffer is invalid")
-	ErrSellOfferInvalid           = fmt.Errorf("SellOffer is invalid")
+	ErrCreatorAccountIndexTooLow         = fmt.Errorf("CreatorAccountIndex should not be less than %d", minAccountIndex)
+	ErrCreatorAccountIndexTooHigh        = fmt.Errorf("CreatorAccountIndex should not be larger than %d", maxAccountIndex)
+	ErrToAccountIndexTooLow              = fmt.Errorf("ToAccountIndex should not be less than %d", minAccountIndex)
+	ErrToAccountIndexTooHigh             = fmt.Errorf("ToAccountIndex should not be larger than %d", maxAccountIndex)
+	ErrToAccountNameHashInvalid          = fmt.Errorf("ToAccountNameHash is invalid")
+	ErrCreatorTreasuryRateTooLow         = fmt.Errorf("CreatorTreasuryRate should  not be less than %d", minTreasuryRate)
+	ErrCreatorTreasuryRateTooHigh        = fmt.Errorf("CreatorTreasuryRate should not be larger than %d", maxTreasuryRate)
+	ErrFromAccountIndexTooLow            = fmt.Errorf("FromAccountIndex should not be less than %d", minAccountIndex)
+	ErrFromAccountIndexTooHigh           = fmt.Errorf("FromAccountIndex should not be larger than %d", maxAccountIndex)
+	ErrToAddressInvalid                  = fmt.Errorf("ToAddress is invalid")
+	ErrToL1AddressInvalid                = fmt.Errorf("ToL1Address is invalid")
+	ErrGasAccountIndexSameAsAccountIndex = fmt.Errorf("GasAccountIndex should not be the same as the paying account index")
+	ErrBuyOfferInvalid                   = fmt.Errorf("BuyOffer is invalid")
+	ErrSellOfferInvalid                  = fmt.Errorf("SellOffer is invalid")
+
+	ErrUnexpectedSignature      = fmt.Errorf("tx info should not carry a signature")
+	ErrExpiredAtNotMilliseconds = fmt.Errorf("ExpiredAt does not look like milliseconds since epoch")
+
+	ErrPubKeyInvalid       = fmt.Errorf("NewPubKey is invalid")
+	ErrL1AddressInvalid    = fmt.Errorf("L1Address is invalid")
+	ErrEthSignatureInvalid = fmt.Errorf("EthSignature should be 65 bytes")
+	ErrAuthModeInvalid     = fmt.Errorf("AuthMode should only be ecdsa(%d) or l1PriorityOp(%d)", AuthModeECDSA, AuthModeL1PriorityOp)
 )
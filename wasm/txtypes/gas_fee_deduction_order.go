@@ -0,0 +1,45 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ValidateSufficientBalanceAfterGasFee checks that assetAmount still fits
+// within balance once gasFeeAssetAmount has already been deducted from it.
+// balance is the from account's pre-tx balance of the asset assetAmount is
+// denominated in; when the tx's gas fee is paid out of that same asset,
+// gasFeeAssetAmount must be taken out first, since checking assetAmount
+// against the undiminished balance would let the two amounts together
+// overdraw the account. When the gas fee is paid in a different asset,
+// callers should pass 0 for gasFeeAssetAmount here (and check the gas fee
+// amount separately against its own asset's balance), since there is then
+// nothing to deduct before this check. The in-circuit counterpart is
+// types.AssertSufficientBalanceAfterGasFee.
+func ValidateSufficientBalanceAfterGasFee(assetAmount, gasFeeAssetAmount, balance *big.Int) error {
+	if assetAmount == nil || gasFeeAssetAmount == nil || balance == nil {
+		return fmt.Errorf("assetAmount, gasFeeAssetAmount and balance should not be nil")
+	}
+	remaining := new(big.Int).Sub(balance, gasFeeAssetAmount)
+	if assetAmount.Cmp(remaining) > 0 {
+		return fmt.Errorf("asset amount exceeds the balance remaining once the gas fee is deducted")
+	}
+	return nil
+}
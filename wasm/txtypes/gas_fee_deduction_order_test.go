@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateSufficientBalanceAfterGasFeeOrderingMatters covers an account
+// that can only afford the tx if the gas fee is taken out after the transfer
+// amount is checked against the raw balance instead of before: the fee and
+// transfer together overdraw the account, but the transfer amount alone does
+// not. ValidateSufficientBalanceAfterGasFee must reject that ordering.
+func TestValidateSufficientBalanceAfterGasFeeOrderingMatters(t *testing.T) {
+	balance := big.NewInt(100)
+	gasFee := big.NewInt(30)
+
+	// fits the raw balance on its own, but not once the gas fee is deducted
+	// first -- the ordering the circuit actually needs to enforce.
+	err := ValidateSufficientBalanceAfterGasFee(big.NewInt(90), gasFee, balance)
+	require.Error(t, err)
+
+	// fits within what's left once the gas fee has already been taken out
+	err = ValidateSufficientBalanceAfterGasFee(big.NewInt(70), gasFee, balance)
+	require.NoError(t, err)
+
+	// exactly exhausting what's left after the fee is still affordable
+	err = ValidateSufficientBalanceAfterGasFee(big.NewInt(70), gasFee, big.NewInt(100))
+	require.NoError(t, err)
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// RemoveLiquidityTxInfo describes a request to burn a user's LP share of a
+// pool and receive back a proportional share of both pooled assets.
+type RemoveLiquidityTxInfo struct {
+	FromAccountIndex  int64
+	PairIndex         int64
+	AssetAId          int64
+	AssetAMinAmount   *big.Int
+	AssetBId          int64
+	AssetBMinAmount   *big.Int
+	LpAmount          *big.Int
+	GasAccountIndex   int64
+	GasFeeAssetId     int64
+	GasFeeAssetAmount *big.Int
+	ExpiredAt         int64
+	Nonce             int64
+}
+
+// StateSnapshot is the minimal read-only view of chain state that previewing
+// a remove-liquidity tx needs: the pool being withdrawn from, its total
+// outstanding LP share count, and the requesting account's own LP balance.
+type StateSnapshot struct {
+	Pool            PoolState
+	TotalLpAmount   *big.Int
+	AccountLpAmount *big.Int
+}
+
+// AccountDeltas is the set of balance changes a tx applies to an account: LP
+// share burned (negative) and pooled assets credited back (positive).
+type AccountDeltas struct {
+	LpDelta     *big.Int
+	AssetADelta *big.Int
+	AssetBDelta *big.Int
+}
+
+// SimulateRemoveLiquidity computes the LP burn and asset credits a
+// remove-liquidity tx would apply against state, without mutating state. The
+// account's share of the pool is assumed proportional to LpAmount /
+// TotalLpAmount, matching the constant-product pool's burn accounting.
+func SimulateRemoveLiquidity(txInfo *RemoveLiquidityTxInfo, state *StateSnapshot) (AccountDeltas, error) {
+	if txInfo == nil {
+		return AccountDeltas{}, errors.New("txInfo should not be nil")
+	}
+	if state == nil {
+		return AccountDeltas{}, errors.New("state should not be nil")
+	}
+	if txInfo.PairIndex != state.Pool.PairIndex {
+		return AccountDeltas{}, errors.New("txInfo PairIndex does not match pool PairIndex")
+	}
+	if txInfo.AssetAId != state.Pool.AssetAId || txInfo.AssetBId != state.Pool.AssetBId {
+		return AccountDeltas{}, errors.New("txInfo asset ids do not match pool asset ids")
+	}
+	if txInfo.LpAmount == nil || txInfo.LpAmount.Sign() <= 0 {
+		return AccountDeltas{}, errors.New("LpAmount should be positive")
+	}
+	if state.TotalLpAmount == nil || state.TotalLpAmount.Sign() <= 0 {
+		return AccountDeltas{}, errors.New("pool has no outstanding LP shares")
+	}
+	if state.AccountLpAmount == nil || txInfo.LpAmount.Cmp(state.AccountLpAmount) > 0 {
+		return AccountDeltas{}, errors.New("LpAmount exceeds the account's LP balance")
+	}
+	if state.Pool.AssetAAmount == nil || state.Pool.AssetBAmount == nil {
+		return AccountDeltas{}, errors.New("pool reserves should not be nil")
+	}
+
+	assetADelta := new(big.Int).Mul(state.Pool.AssetAAmount, txInfo.LpAmount)
+	assetADelta.Div(assetADelta, state.TotalLpAmount)
+	assetBDelta := new(big.Int).Mul(state.Pool.AssetBAmount, txInfo.LpAmount)
+	assetBDelta.Div(assetBDelta, state.TotalLpAmount)
+
+	if txInfo.AssetAMinAmount != nil && assetADelta.Cmp(txInfo.AssetAMinAmount) < 0 {
+		return AccountDeltas{}, errors.New("AssetADelta is below AssetAMinAmount")
+	}
+	if txInfo.AssetBMinAmount != nil && assetBDelta.Cmp(txInfo.AssetBMinAmount) < 0 {
+		return AccountDeltas{}, errors.New("AssetBDelta is below AssetBMinAmount")
+	}
+
+	return AccountDeltas{
+		LpDelta:     new(big.Int).Neg(txInfo.LpAmount),
+		AssetADelta: assetADelta,
+		AssetBDelta: assetBDelta,
+	}, nil
+}
+
+// ValidateRemoveLiquidity rejects a remove-liquidity request whose computed
+// deltas would drop either pool reserve below minReserve, so a pool can't be
+// drained to the point a future swap or remove against it has nothing left
+// to work with.
+func ValidateRemoveLiquidity(txInfo *RemoveLiquidityTxInfo, state *StateSnapshot, minReserve *big.Int) error {
+	if minReserve == nil {
+		return errors.New("minReserve should not be nil")
+	}
+	deltas, err := SimulateRemoveLiquidity(txInfo, state)
+	if err != nil {
+		return err
+	}
+	remainingA := new(big.Int).Sub(state.Pool.AssetAAmount, deltas.AssetADelta)
+	remainingB := new(big.Int).Sub(state.Pool.AssetBAmount, deltas.AssetBDelta)
+	if remainingA.Cmp(minReserve) < 0 {
+		return errors.New("remove would drop the pool's AssetA reserve below minReserve")
+	}
+	if remainingB.Cmp(minReserve) < 0 {
+		return errors.New("remove would drop the pool's AssetB reserve below minReserve")
+	}
+	return nil
+}
+
+// BlockLpSupplyDelta sums the change in pairIndex's outstanding LP share
+// count a block of removes applies, using the same LpDelta a single
+// SimulateRemoveLiquidity call would report (negative, since a remove burns
+// LP shares). This package does not yet have an add-liquidity tx type to
+// mint LP shares with, so the sum only ever moves in one direction; once one
+// exists, its mints should be folded in here the same way, with the
+// opposite sign.
+func BlockLpSupplyDelta(pairIndex int64, removes []*RemoveLiquidityTxInfo, states []*StateSnapshot) (*big.Int, error) {
+	if len(removes) != len(states) {
+		return nil, errors.New("removes and states should have the same length")
+	}
+	delta := big.NewInt(0)
+	for i, txInfo := range removes {
+		if txInfo == nil || txInfo.PairIndex != pairIndex {
+			continue
+		}
+		deltas, err := SimulateRemoveLiquidity(txInfo, states[i])
+		if err != nil {
+			return nil, err
+		}
+		delta.Add(delta, deltas.LpDelta)
+	}
+	return delta, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateRemoveLiquidity(t *testing.T) {
+	state := &StateSnapshot{
+		Pool: PoolState{
+			PairIndex:    1,
+			AssetAId:     0,
+			AssetAAmount: big.NewInt(1000000),
+			AssetBId:     1,
+			AssetBAmount: big.NewInt(2000000),
+		},
+		TotalLpAmount:   big.NewInt(1000000),
+		AccountLpAmount: big.NewInt(100000),
+	}
+
+	deltas, err := SimulateRemoveLiquidity(&RemoveLiquidityTxInfo{
+		PairIndex: 1,
+		AssetAId:  0,
+		AssetBId:  1,
+		LpAmount:  big.NewInt(100000),
+	}, state)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-100000), deltas.LpDelta)
+	require.Equal(t, big.NewInt(100000), deltas.AssetADelta)
+	require.Equal(t, big.NewInt(200000), deltas.AssetBDelta)
+
+	// exceeds the account's LP balance
+	_, err = SimulateRemoveLiquidity(&RemoveLiquidityTxInfo{
+		PairIndex: 1,
+		AssetAId:  0,
+		AssetBId:  1,
+		LpAmount:  big.NewInt(200000),
+	}, state)
+	require.Error(t, err)
+
+	// slippage protection rejects a min amount the burn can't satisfy
+	_, err = SimulateRemoveLiquidity(&RemoveLiquidityTxInfo{
+		PairIndex:       1,
+		AssetAId:        0,
+		AssetBId:        1,
+		LpAmount:        big.NewInt(100000),
+		AssetAMinAmount: big.NewInt(200000),
+	}, state)
+	require.Error(t, err)
+}
+
+func TestValidateRemoveLiquidity(t *testing.T) {
+	state := &StateSnapshot{
+		Pool: PoolState{
+			PairIndex:    1,
+			AssetAId:     0,
+			AssetAAmount: big.NewInt(1000000),
+			AssetBId:     1,
+			AssetBAmount: big.NewInt(2000000),
+		},
+		TotalLpAmount:   big.NewInt(1000000),
+		AccountLpAmount: big.NewInt(900000),
+	}
+
+	// leaves plenty of reserve above the minimum
+	err := ValidateRemoveLiquidity(&RemoveLiquidityTxInfo{
+		PairIndex: 1,
+		AssetAId:  0,
+		AssetBId:  1,
+		LpAmount:  big.NewInt(100000),
+	}, state, big.NewInt(500000))
+	require.NoError(t, err)
+
+	// would drain AssetA below the minimum reserve
+	err = ValidateRemoveLiquidity(&RemoveLiquidityTxInfo{
+		PairIndex: 1,
+		AssetAId:  0,
+		AssetBId:  1,
+		LpAmount:  big.NewInt(900000),
+	}, state, big.NewInt(500000))
+	require.Error(t, err)
+}
+
+// TestBlockLpSupplyDelta covers a block of two removes against pair 1 plus a
+// third against a different pair that must be excluded. This package does
+// not yet have an add-liquidity tx type, so there is nothing to mint with;
+// BlockLpSupplyDelta's doc comment explains how an add's delta would fold in
+// once one exists.
+func TestBlockLpSupplyDelta(t *testing.T) {
+	pair1State := &StateSnapshot{
+		Pool: PoolState{
+			PairIndex:    1,
+			AssetAId:     0,
+			AssetAAmount: big.NewInt(1000000),
+			AssetBId:     1,
+			AssetBAmount: big.NewInt(2000000),
+		},
+		TotalLpAmount:   big.NewInt(1000000),
+		AccountLpAmount: big.NewInt(500000),
+	}
+	pair2State := &StateSnapshot{
+		Pool: PoolState{
+			PairIndex:    2,
+			AssetAId:     2,
+			AssetAAmount: big.NewInt(1000000),
+			AssetBId:     3,
+			AssetBAmount: big.NewInt(2000000),
+		},
+		TotalLpAmount:   big.NewInt(1000000),
+		AccountLpAmount: big.NewInt(500000),
+	}
+
+	removes := []*RemoveLiquidityTxInfo{
+		{PairIndex: 1, AssetAId: 0, AssetBId: 1, LpAmount: big.NewInt(100000)},
+		{PairIndex: 1, AssetAId: 0, AssetBId: 1, LpAmount: big.NewInt(50000)},
+		{PairIndex: 2, AssetAId: 2, AssetBId: 3, LpAmount: big.NewInt(100000)},
+	}
+	states := []*StateSnapshot{pair1State, pair1State, pair2State}
+
+	delta, err := BlockLpSupplyDelta(1, removes, states)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-150000), delta)
+
+	delta, err = BlockLpSupplyDelta(2, removes, states)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-100000), delta)
+
+	// length mismatch
+	_, err = BlockLpSupplyDelta(1, removes, states[:1])
+	require.Error(t, err)
+}
@@ -184,6 +184,11 @@ func (txInfo *MintNftTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	return nil
 }
 
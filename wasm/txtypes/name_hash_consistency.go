@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ValidateNameHashConsistency checks that a tx's own copy of an account name
+// hash (e.g. WithdrawNftTxInfo.CreatorAccountNameHash) matches the name hash
+// actually committed on that account's leaf. A tx type carries its own copy
+// of the hash so the circuit can check it against the witnessed account
+// without having to read the whole account name back out of the tree; this
+// is the native counterpart of the equality the circuit already enforces
+// (see types.AssertNameHashConsistency), letting a caller validate the pair
+// before ever building a witness.
+func ValidateNameHashConsistency(txNameHash, accountNameHash []byte) error {
+	if !bytes.Equal(txNameHash, accountNameHash) {
+		return errors.New("tx account name hash does not match the account's committed name hash")
+	}
+	return nil
+}
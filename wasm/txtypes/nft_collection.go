@@ -0,0 +1,35 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "fmt"
+
+// ValidateNftCollectionId checks that a CollectionId carried on an NFT tx
+// (WithdrawNftTxInfo, FullExitNftTxInfo, ...) matches the CollectionId
+// already committed for that NFT in layer-2 state. These tx infos are built
+// from layer-1 events and synced account/nft data outside this package, so
+// the committed value isn't available inside Validate() itself; callers that
+// hold both values should run this check before handing the tx info off to
+// witness construction, the same cross-check VerifyWithdrawNftTx and
+// VerifyFullExitNftTx apply in-circuit against the committed nft leaf.
+func ValidateNftCollectionId(claimedCollectionId, committedCollectionId int64) error {
+	if claimedCollectionId != committedCollectionId {
+		return fmt.Errorf("nft collection id %d does not match the nft's committed collection id %d", claimedCollectionId, committedCollectionId)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "reflect"
+
+// noSignatureTxTypes are authorized by an L1 event rather than an L2
+// signature: deposit, deposit-nft and register-zns tx infos must never carry
+// a populated "Sig" field.
+var noSignatureTxTypes = map[uint8]bool{
+	TxTypeDeposit:     true,
+	TxTypeDepositNft:  true,
+	TxTypeRegisterZns: true,
+}
+
+// ValidateNoSignatureTxInfo rejects a tx info for an L1-authorized tx type
+// that carries a populated "Sig" field. It is a no-op for any other tx type,
+// and for a tx info struct with no "Sig" field at all, since none of today's
+// deposit/deposit-nft/register-zns structs declare one.
+func ValidateNoSignatureTxInfo(txType uint8, txInfo interface{}) error {
+	if !noSignatureTxTypes[txType] {
+		return nil
+	}
+
+	v := reflect.ValueOf(txInfo)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	sigField := v.FieldByName("Sig")
+	if !sigField.IsValid() || sigField.IsZero() {
+		return nil
+	}
+	return ErrUnexpectedSignature
+}
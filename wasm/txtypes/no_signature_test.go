@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNoSignatureTxInfo(t *testing.T) {
+	require.NoError(t, ValidateNoSignatureTxInfo(TxTypeDeposit, &DepositTxInfo{}))
+	require.NoError(t, ValidateNoSignatureTxInfo(TxTypeTransfer, &TransferTxInfo{}))
+
+	// malformedDepositTxInfo simulates a wasm construction bug that populates a
+	// Sig field DepositTxInfo itself doesn't declare.
+	type malformedDepositTxInfo struct {
+		DepositTxInfo
+		Sig []byte
+	}
+	err := ValidateNoSignatureTxInfo(TxTypeDeposit, &malformedDepositTxInfo{Sig: []byte{1, 2, 3}})
+	require.ErrorIs(t, err, ErrUnexpectedSignature)
+}
@@ -25,6 +25,7 @@ import (
 	"hash"
 	"log"
 	"math/big"
+	"sort"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 )
@@ -160,6 +161,11 @@ func (txInfo *OfferTxInfo) Validate() error {
 	if txInfo.TreasuryRate > maxTreasuryRate {
 		return ErrTreasuryRateTooHigh
 	}
+
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -222,3 +228,31 @@ func (txInfo *OfferTxInfo) Hash(hFunc hash.Hash) (msgHash []byte, err error) {
 func (txInfo *OfferTxInfo) GetGas() (int64, int64, *big.Int) {
 	return NilAccountIndex, NilAssetId, nil
 }
+
+// HashOfferSet computes a canonical digest of a set of offers, independent of
+// their input order. Offers are first sorted by (AccountIndex, OfferId), then
+// each offer's own Hash is folded into a single running hash.
+func HashOfferSet(offers []*OfferTxInfo) ([]byte, error) {
+	sorted := make([]*OfferTxInfo, len(offers))
+	copy(sorted, offers)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].AccountIndex != sorted[j].AccountIndex {
+			return sorted[i].AccountIndex < sorted[j].AccountIndex
+		}
+		return sorted[i].OfferId < sorted[j].OfferId
+	})
+
+	hFunc := mimc.NewMiMC()
+	acc := make([]byte, 0)
+	for _, offer := range sorted {
+		offerHash, err := offer.Hash(hFunc)
+		if err != nil {
+			return nil, err
+		}
+		hFunc.Reset()
+		hFunc.Write(acc)
+		hFunc.Write(offerHash)
+		acc = hFunc.Sum(nil)
+	}
+	return acc, nil
+}
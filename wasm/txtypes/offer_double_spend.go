@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "fmt"
+
+// offerKey identifies an offer the way the circuit's OfferCanceledOrFinalized
+// bitmap does: the bit an offer occupies lives on its own account, so two
+// offers only collide if they share both AccountIndex and OfferId.
+type offerKey struct {
+	AccountIndex int64
+	OfferId      int64
+}
+
+// CheckOfferDoubleSpend scans a block's atomic matches for an offer consumed
+// more than once. Within a single block the circuit only guards against this
+// per tx, by checking each match's offer bit against the account state
+// witnessed for that tx; it does not by itself stop two matches earlier in
+// the same block from both being built against the same not-yet-updated
+// account state and so both claiming the same offer. This native scan is
+// the block-level counterpart of that per-tx bit check, meant to run once
+// over the whole block before its txs are proved.
+func CheckOfferDoubleSpend(matches []*AtomicMatchTxInfo) error {
+	seen := make(map[offerKey]bool, len(matches)*2)
+	for _, match := range matches {
+		if match == nil {
+			continue
+		}
+		for _, offer := range []*OfferTxInfo{match.BuyOffer, match.SellOffer} {
+			if offer == nil {
+				continue
+			}
+			key := offerKey{AccountIndex: offer.AccountIndex, OfferId: offer.OfferId}
+			if seen[key] {
+				return fmt.Errorf("offer %d on account %d is matched more than once in this block", offer.OfferId, offer.AccountIndex)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOfferDoubleSpendCleanBlock(t *testing.T) {
+	matches := []*AtomicMatchTxInfo{
+		{
+			BuyOffer:  &OfferTxInfo{AccountIndex: 1, OfferId: 1},
+			SellOffer: &OfferTxInfo{AccountIndex: 2, OfferId: 1},
+		},
+		{
+			BuyOffer:  &OfferTxInfo{AccountIndex: 3, OfferId: 1},
+			SellOffer: &OfferTxInfo{AccountIndex: 4, OfferId: 2},
+		},
+	}
+	require.NoError(t, CheckOfferDoubleSpend(matches))
+}
+
+func TestCheckOfferDoubleSpendRejectsDoubleMatch(t *testing.T) {
+	matches := []*AtomicMatchTxInfo{
+		{
+			BuyOffer:  &OfferTxInfo{AccountIndex: 1, OfferId: 1},
+			SellOffer: &OfferTxInfo{AccountIndex: 2, OfferId: 1},
+		},
+		{
+			// account 2's offer 1 (sold above) is matched again as a buy
+			BuyOffer:  &OfferTxInfo{AccountIndex: 2, OfferId: 1},
+			SellOffer: &OfferTxInfo{AccountIndex: 4, OfferId: 2},
+		},
+	}
+	require.Error(t, CheckOfferDoubleSpend(matches))
+}
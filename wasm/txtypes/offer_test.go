@@ -185,3 +185,22 @@ func TestValidateOfferTxInfo(t *testing.T) {
 		require.Equalf(t, err, testCase.err, fmt.Sprintf("case %d: err should be the same", index))
 	}
 }
+
+func TestHashOfferSet(t *testing.T) {
+	offers := []*OfferTxInfo{
+		{Type: SellOfferType, OfferId: 1, AccountIndex: 3, NftIndex: 4, AssetId: 10, AssetAmount: big.NewInt(20), ListedAt: 1, ExpiredAt: 2, TreasuryRate: 200},
+		{Type: BuyOfferType, OfferId: 2, AccountIndex: 1, NftIndex: 5, AssetId: 11, AssetAmount: big.NewInt(21), ListedAt: 1, ExpiredAt: 2, TreasuryRate: 200},
+		{Type: SellOfferType, OfferId: 3, AccountIndex: 2, NftIndex: 6, AssetId: 12, AssetAmount: big.NewInt(22), ListedAt: 1, ExpiredAt: 2, TreasuryRate: 200},
+	}
+	permuted := []*OfferTxInfo{offers[2], offers[0], offers[1]}
+
+	hash1, err := HashOfferSet(offers)
+	require.NoError(t, err)
+	hash2, err := HashOfferSet(permuted)
+	require.NoError(t, err)
+	require.Equal(t, hash1, hash2)
+
+	hash3, err := HashOfferSet(offers[:2])
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash3)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "fmt"
+
+// PoolAccountIndex is meant to map a pool's PairIndex to the account index
+// of the leaf that holds its reserves, the way ValidateSwapAmounts and
+// SimulateRemoveLiquidity's PoolState parameter is read from today. This
+// package, however, has no committed derivation to match: a pool is
+// referenced solely by PairIndex everywhere in this repo (PoolState,
+// SwapTxInfo, RemoveLiquidityTxInfo), and no account leaf, witness field, or
+// constant anywhere ties a PairIndex to an account index. There is
+// therefore no "protocol's derivation" here to reproduce.
+//
+// This placeholder returns pairIndex itself — a pool account index equal to
+// its pair index is a convention some other L2 designs use, but it is not
+// one this codebase has adopted anywhere else, so treat this as a recorded
+// gap rather than a real derivation. It only validates that pairIndex is
+// non-negative, since a negative pair index cannot correspond to any real
+// pool.
+func PoolAccountIndex(pairIndex int64) (int64, error) {
+	if pairIndex < 0 {
+		return 0, fmt.Errorf("pairIndex should not be negative: %d", pairIndex)
+	}
+	return pairIndex, nil
+}
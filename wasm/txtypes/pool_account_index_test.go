@@ -0,0 +1,19 @@
+package txtypes
+
+import "testing"
+
+func TestPoolAccountIndex(t *testing.T) {
+	for _, pairIndex := range []int64{0, 1, 42} {
+		accountIndex, err := PoolAccountIndex(pairIndex)
+		if err != nil {
+			t.Fatalf("PoolAccountIndex(%d) failed: %v", pairIndex, err)
+		}
+		if accountIndex != pairIndex {
+			t.Fatalf("PoolAccountIndex(%d) = %d, want %d", pairIndex, accountIndex, pairIndex)
+		}
+	}
+
+	if _, err := PoolAccountIndex(-1); err == nil {
+		t.Fatalf("expected an error for a negative pairIndex")
+	}
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "errors"
+
+// ValidateMatchingPoolAssetIds checks that a swap or liquidity tx's declared
+// asset pair matches the pool it is settling against. ValidateSwapAmounts
+// and SimulateRemoveLiquidity already inline this same comparison against
+// their own PoolState parameter; this is a standalone, reusable form of that
+// bound for callers, such as a circuit witness builder, that need to check
+// it before a full ValidateSwapAmounts/SimulateRemoveLiquidity call is
+// appropriate. The in-circuit counterpart is
+// types.AssertMatchingPoolAssetIds.
+func ValidateMatchingPoolAssetIds(txAssetAId, txAssetBId, poolAssetAId, poolAssetBId int64) error {
+	if txAssetAId != poolAssetAId || txAssetBId != poolAssetBId {
+		return errors.New("tx AssetAId/AssetBId does not match the pool's asset pair")
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package txtypes
+
+import "testing"
+
+func TestValidateMatchingPoolAssetIdsRejectsMismatch(t *testing.T) {
+	if err := ValidateMatchingPoolAssetIds(1, 2, 1, 3); err == nil {
+		t.Fatalf("expected an error when AssetBId does not match the pool")
+	}
+	if err := ValidateMatchingPoolAssetIds(1, 2, 4, 2); err == nil {
+		t.Fatalf("expected an error when AssetAId does not match the pool")
+	}
+	if err := ValidateMatchingPoolAssetIds(1, 2, 1, 2); err != nil {
+		t.Fatalf("ValidateMatchingPoolAssetIds with a matching pair failed: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
+	"github.com/pkg/errors"
+)
+
+// txInfoConstructors maps a TxType to a zero-value constructor for its
+// concrete TxInfo, so ProcessSignedTx can unmarshal txJson into the right
+// concrete struct before dispatching through the TxInfo interface.
+var txInfoConstructors = map[uint8]func() TxInfo{
+	TxTypeRegisterZns:      func() TxInfo { return &RegisterZnsTxInfo{} },
+	TxTypeDeposit:          func() TxInfo { return &DepositTxInfo{} },
+	TxTypeDepositNft:       func() TxInfo { return &DepositNftTxInfo{} },
+	TxTypeTransfer:         func() TxInfo { return &TransferTxInfo{} },
+	TxTypeWithdraw:         func() TxInfo { return &WithdrawTxInfo{} },
+	TxTypeCreateCollection: func() TxInfo { return &CreateCollectionTxInfo{} },
+	TxTypeMintNft:          func() TxInfo { return &MintNftTxInfo{} },
+	TxTypeTransferNft:      func() TxInfo { return &TransferNftTxInfo{} },
+	TxTypeAtomicMatch:      func() TxInfo { return &AtomicMatchTxInfo{} },
+	TxTypeCancelOffer:      func() TxInfo { return &CancelOfferTxInfo{} },
+	TxTypeWithdrawNft:      func() TxInfo { return &WithdrawNftTxInfo{} },
+	TxTypeFullExit:         func() TxInfo { return &FullExitTxInfo{} },
+	TxTypeFullExitNft:      func() TxInfo { return &FullExitNftTxInfo{} },
+	TxTypeOffer:            func() TxInfo { return &OfferTxInfo{} },
+}
+
+// ProcessSignedTx is a single entrypoint combining the steps an integrator
+// would otherwise call one at a time: unmarshal txJson into the concrete
+// TxInfo for txType, run its own Validate, reject it if it is already
+// expired as of now, and check its signature against pk. Each stage's
+// error is wrapped with which stage produced it, so a caller gets an
+// aggregated picture of where a bad tx failed rather than a bare message.
+// On success it returns the parsed, validated TxInfo.
+//
+// ProcessSignedTx does not normalize address or amount fields: the returned
+// TxInfo carries them exactly as parsed from txJson. Those fields live on
+// each concrete tx info struct under different names (ToAddress,
+// NftL1Address, AssetAmount, GasFeeAssetAmount, ...), with no common setter
+// on the TxInfo interface, and each concrete Validate only checks its own
+// fields without rewriting them (e.g. TransferTxInfo.Validate accepts
+// ToL1Address in any hex case via IsValidL1Address and leaves it as given).
+// CleanPackedFee/CleanPackedAmount rounding is applied by the
+// ConstructXxxTxInfo helpers, not here. The one value re-derived here is
+// pk's own wire format, via hex.EncodeToString rather than trusting a
+// caller-supplied string, since VerifySignature takes a string and every
+// concrete VerifySignature re-parses it with ParsePublicKey anyway.
+func ProcessSignedTx(txType uint8, txJson string, pk *eddsa.PublicKey, now int64) (interface{}, error) {
+	if pk == nil {
+		return nil, fmt.Errorf("pk should not be nil")
+	}
+	newTxInfo, ok := txInfoConstructors[txType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported tx type %d", txType)
+	}
+
+	txInfo := newTxInfo()
+	if err := json.Unmarshal([]byte(txJson), txInfo); err != nil {
+		return nil, errors.Wrap(err, "parse")
+	}
+	if err := txInfo.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validate")
+	}
+	if expiredAt := txInfo.GetExpiredAt(); expiredAt != NilExpiredAt && expiredAt < now {
+		return nil, fmt.Errorf("tx expired at %d, now is %d", expiredAt, now)
+	}
+	if err := txInfo.VerifySignature(hex.EncodeToString(pk.Bytes())); err != nil {
+		return nil, errors.Wrap(err, "verify signature")
+	}
+
+	return txInfo, nil
+}
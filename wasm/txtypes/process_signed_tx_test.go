@@ -0,0 +1,110 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
+)
+
+func signedTransferJson(t *testing.T, sk *PrivateKey, mutate func(*TransferTxInfo)) string {
+	t.Helper()
+	txInfo := &TransferTxInfo{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		ToAccountNameHash: hex.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+		AssetId:           3,
+		AssetAmount:       big.NewInt(100),
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+		ExpiredAt:         2_000_000_000_000,
+		Nonce:             1,
+		CallDataHash:      bytes.Repeat([]byte{1}, 32),
+	}
+	if mutate != nil {
+		mutate(txInfo)
+	}
+	msgHash, err := txInfo.Hash(mimc.NewMiMC())
+	require.NoError(t, err)
+	sig, err := sk.Sign(msgHash, mimc.NewMiMC())
+	require.NoError(t, err)
+	txInfo.Sig = sig
+
+	txJson, err := json.Marshal(txInfo)
+	require.NoError(t, err)
+	return string(txJson)
+}
+
+func TestProcessSignedTxFullyValid(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("process-signed-tx-seed")
+	require.NoError(t, err)
+	txJson := signedTransferJson(t, sk, nil)
+
+	result, err := ProcessSignedTx(TxTypeTransfer, txJson, &sk.PublicKey, 1_700_000_000_000)
+	require.NoError(t, err)
+	txInfo, ok := result.(*TransferTxInfo)
+	require.True(t, ok)
+	require.Equal(t, int64(1), txInfo.FromAccountIndex)
+}
+
+func TestProcessSignedTxFailsAtEachStage(t *testing.T) {
+	sk, err := curve.GenerateEddsaPrivateKey("process-signed-tx-seed")
+	require.NoError(t, err)
+	otherSk, err := curve.GenerateEddsaPrivateKey("another-seed")
+	require.NoError(t, err)
+
+	// unsupported tx type
+	_, err = ProcessSignedTx(255, "{}", &sk.PublicKey, 1_700_000_000_000)
+	require.Error(t, err)
+
+	// parse failure: malformed JSON
+	_, err = ProcessSignedTx(TxTypeTransfer, "not json", &sk.PublicKey, 1_700_000_000_000)
+	require.Error(t, err)
+
+	// validate failure: FromAccountIndex out of range makes the tx invalid
+	invalidJson := signedTransferJson(t, sk, func(txInfo *TransferTxInfo) {
+		txInfo.FromAccountIndex = -1
+	})
+	_, err = ProcessSignedTx(TxTypeTransfer, invalidJson, &sk.PublicKey, 1_700_000_000_000)
+	require.Error(t, err)
+
+	// expired: ExpiredAt already passed as of now
+	expiredJson := signedTransferJson(t, sk, func(txInfo *TransferTxInfo) {
+		txInfo.ExpiredAt = 1_000_000_000_000
+	})
+	_, err = ProcessSignedTx(TxTypeTransfer, expiredJson, &sk.PublicKey, 1_000_000_000_001)
+	require.Error(t, err)
+
+	// signature failure: verify against the wrong public key
+	validJson := signedTransferJson(t, sk, nil)
+	_, err = ProcessSignedTx(TxTypeTransfer, validJson, &otherSk.PublicKey, 1_700_000_000_000)
+	require.Error(t, err)
+
+	// nil pk
+	_, err = ProcessSignedTx(TxTypeTransfer, validJson, nil, 1_700_000_000_000)
+	require.Error(t, err)
+}
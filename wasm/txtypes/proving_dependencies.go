@@ -0,0 +1,175 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "fmt"
+
+// ProvingDependencies returns the account indices, per-asset asset ids and
+// nft indices that must be fetched from state in order to build the proving
+// witness for txInfo. The asset ids are grouped by the account that owns
+// them, since a sequencer fetches account-asset leaves per account. An index
+// is listed once per role it plays in txInfo, so the slices are not
+// deduplicated (e.g. an atomic match lists its nft once for each offer).
+func ProvingDependencies(txType uint8, txInfo interface{}) (accounts []int64, assets map[int64][]int64, nfts []int64, err error) {
+	assets = make(map[int64][]int64)
+	addAsset := func(account, asset int64) {
+		if account == NilAccountIndex || asset == NilAssetId {
+			return
+		}
+		assets[account] = append(assets[account], asset)
+	}
+	addAccount := func(account int64) {
+		if account == NilAccountIndex {
+			return
+		}
+		accounts = append(accounts, account)
+	}
+
+	switch txType {
+	case TxTypeTransfer:
+		txInfo, ok := txInfo.(*TransferTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeTransfer")
+		}
+		addAccount(txInfo.FromAccountIndex)
+		addAccount(txInfo.ToAccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.FromAccountIndex, txInfo.AssetId)
+		addAsset(txInfo.FromAccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+	case TxTypeWithdraw:
+		txInfo, ok := txInfo.(*WithdrawTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeWithdraw")
+		}
+		addAccount(txInfo.FromAccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.FromAccountIndex, txInfo.AssetId)
+		addAsset(txInfo.FromAccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+	case TxTypeCreateCollection:
+		txInfo, ok := txInfo.(*CreateCollectionTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeCreateCollection")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+	case TxTypeMintNft:
+		txInfo, ok := txInfo.(*MintNftTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeMintNft")
+		}
+		addAccount(txInfo.CreatorAccountIndex)
+		addAccount(txInfo.ToAccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.CreatorAccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+		nfts = append(nfts, txInfo.NftIndex)
+	case TxTypeTransferNft:
+		txInfo, ok := txInfo.(*TransferNftTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeTransferNft")
+		}
+		addAccount(txInfo.FromAccountIndex)
+		addAccount(txInfo.ToAccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.FromAccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+		nfts = append(nfts, txInfo.NftIndex)
+	case TxTypeAtomicMatch:
+		txInfo, ok := txInfo.(*AtomicMatchTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeAtomicMatch")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+		if txInfo.BuyOffer != nil {
+			addAccount(txInfo.BuyOffer.AccountIndex)
+			addAsset(txInfo.BuyOffer.AccountIndex, txInfo.BuyOffer.AssetId)
+			nfts = append(nfts, txInfo.BuyOffer.NftIndex)
+		}
+		if txInfo.SellOffer != nil {
+			addAccount(txInfo.SellOffer.AccountIndex)
+			addAsset(txInfo.SellOffer.AccountIndex, txInfo.SellOffer.AssetId)
+			nfts = append(nfts, txInfo.SellOffer.NftIndex)
+		}
+	case TxTypeCancelOffer:
+		txInfo, ok := txInfo.(*CancelOfferTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeCancelOffer")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+	case TxTypeWithdrawNft:
+		txInfo, ok := txInfo.(*WithdrawNftTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeWithdrawNft")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.CreatorAccountIndex)
+		addAccount(txInfo.GasAccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.GasFeeAssetId)
+		addAsset(txInfo.GasAccountIndex, txInfo.GasFeeAssetId)
+		nfts = append(nfts, txInfo.NftIndex)
+	case TxTypeFullExit:
+		txInfo, ok := txInfo.(*FullExitTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeFullExit")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.AssetId)
+	case TxTypeFullExitNft:
+		txInfo, ok := txInfo.(*FullExitNftTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeFullExitNft")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.CreatorAccountIndex)
+		nfts = append(nfts, txInfo.NftIndex)
+	case TxTypeDeposit:
+		txInfo, ok := txInfo.(*DepositTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeDeposit")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAsset(txInfo.AccountIndex, txInfo.AssetId)
+	case TxTypeDepositNft:
+		txInfo, ok := txInfo.(*DepositNftTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeDepositNft")
+		}
+		addAccount(txInfo.AccountIndex)
+		addAccount(txInfo.CreatorAccountIndex)
+		nfts = append(nfts, txInfo.NftIndex)
+	case TxTypeRegisterZns:
+		txInfo, ok := txInfo.(*RegisterZnsTxInfo)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid tx info type for TxTypeRegisterZns")
+		}
+		addAccount(txInfo.AccountIndex)
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported tx type: %d", txType)
+	}
+	return accounts, assets, nfts, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SwapTxInfo has no TxType constant and no ProvingDependencies case, so
+// buy-nft (TxTypeAtomicMatch) is used as the representative multi-account
+// case.
+func TestProvingDependenciesAtomicMatch(t *testing.T) {
+	txInfo := &AtomicMatchTxInfo{
+		AccountIndex: 1,
+		BuyOffer: &OfferTxInfo{
+			AccountIndex: 2,
+			NftIndex:     10,
+			AssetId:      0,
+		},
+		SellOffer: &OfferTxInfo{
+			AccountIndex: 3,
+			NftIndex:     10,
+			AssetId:      0,
+		},
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+	}
+
+	accounts, assets, nfts, err := ProvingDependencies(TxTypeAtomicMatch, txInfo)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 4, 2, 3}, accounts)
+	require.ElementsMatch(t, []int64{10, 10}, nfts)
+	require.Contains(t, assets[1], int64(0))
+	require.Contains(t, assets[2], int64(0))
+	require.Contains(t, assets[3], int64(0))
+	require.Contains(t, assets[4], int64(0))
+}
+
+func TestProvingDependenciesTransfer(t *testing.T) {
+	txInfo := &TransferTxInfo{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		AssetId:           3,
+		AssetAmount:       big.NewInt(100),
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+	}
+
+	accounts, assets, nfts, err := ProvingDependencies(TxTypeTransfer, txInfo)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2, 4}, accounts)
+	require.Empty(t, nfts)
+	require.ElementsMatch(t, []int64{3, 0}, assets[1])
+	require.ElementsMatch(t, []int64{0}, assets[4])
+}
+
+func TestProvingDependenciesUnsupportedType(t *testing.T) {
+	_, _, _, err := ProvingDependencies(255, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+// SigMessage returns the message hash that a tx's signature should cover,
+// without requiring (or checking) a public key. It lets middleware confirm
+// that a stored hash input is internally consistent with the tx contents
+// before a signature is even available to verify against.
+func SigMessage(txType uint8, txInfo interface{}) ([]byte, error) {
+	tx, ok := txInfo.(TxInfo)
+	if !ok {
+		return nil, fmt.Errorf("invalid tx info type for tx type %d", txType)
+	}
+	if tx.GetTxType() != int(txType) {
+		return nil, fmt.Errorf("tx info type %d does not match tx type %d", tx.GetTxType(), txType)
+	}
+	return tx.Hash(mimc.NewMiMC())
+}
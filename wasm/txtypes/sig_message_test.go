@@ -0,0 +1,83 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigMessagePerType(t *testing.T) {
+	transfer := &TransferTxInfo{
+		FromAccountIndex:  1,
+		ToAccountIndex:    2,
+		ToAccountNameHash: "0x01",
+		AssetId:           3,
+		AssetAmount:       big.NewInt(100),
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+		ExpiredAt:         1000,
+		Nonce:             1,
+	}
+	offer := &OfferTxInfo{
+		Type:         SellOfferType,
+		OfferId:      1,
+		AccountIndex: 1,
+		NftIndex:     2,
+		AssetId:      0,
+		AssetAmount:  big.NewInt(10),
+		ListedAt:     1,
+		ExpiredAt:    2,
+		TreasuryRate: 200,
+	}
+	mintNft := &MintNftTxInfo{
+		CreatorAccountIndex: 1,
+		ToAccountIndex:      2,
+		ToAccountNameHash:   "0x01",
+		NftContentHash:      "0x02",
+		GasAccountIndex:     4,
+		GasFeeAssetId:       0,
+		GasFeeAssetAmount:   big.NewInt(1),
+		ExpiredAt:           1000,
+		Nonce:               1,
+	}
+
+	cases := []struct {
+		txType uint8
+		txInfo TxInfo
+	}{
+		{TxTypeTransfer, transfer},
+		{TxTypeOffer, offer},
+		{TxTypeMintNft, mintNft},
+	}
+
+	for _, c := range cases {
+		want, err := c.txInfo.Hash(mimc.NewMiMC())
+		require.NoError(t, err)
+		got, err := SigMessage(c.txType, c.txInfo)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err := SigMessage(TxTypeOffer, transfer)
+	require.Error(t, err)
+}
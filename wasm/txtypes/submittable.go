@@ -0,0 +1,18 @@
+package txtypes
+
+import "fmt"
+
+// IsSubmittable reports whether txInfo can still be submitted at time now
+// (a UnixMilli timestamp, matching GetExpiredAt/ExpiredAt across this
+// package): its signed expiry has not passed and it satisfies Validate().
+// It returns a human-readable reason alongside a false result, so a wallet
+// can surface why a tx was rejected without re-deriving the check itself.
+func IsSubmittable(txInfo TxInfo, now int64) (bool, string) {
+	if now >= txInfo.GetExpiredAt() {
+		return false, fmt.Sprintf("tx expired at %d, now is %d", txInfo.GetExpiredAt(), now)
+	}
+	if err := txInfo.Validate(); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
@@ -0,0 +1,45 @@
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validWithdrawTxInfo(expiredAt int64) *WithdrawTxInfo {
+	return &WithdrawTxInfo{
+		FromAccountIndex:  1,
+		AssetId:           1,
+		AssetAmount:       big.NewInt(1),
+		GasAccountIndex:   0,
+		GasFeeAssetId:     3,
+		GasFeeAssetAmount: big.NewInt(100),
+		ToAddress:         "0x299d17c8b4e9967385dc9a3bb78f2a43f5a13bd0",
+		ExpiredAt:         expiredAt,
+		Nonce:             1,
+	}
+}
+
+func TestIsSubmittableValid(t *testing.T) {
+	txInfo := validWithdrawTxInfo(time.Now().Add(time.Hour).UnixMilli())
+	ok, reason := IsSubmittable(txInfo, time.Now().UnixMilli())
+	require.True(t, ok)
+	require.Empty(t, reason)
+}
+
+func TestIsSubmittableExpired(t *testing.T) {
+	txInfo := validWithdrawTxInfo(time.Now().Add(-time.Hour).UnixMilli())
+	ok, reason := IsSubmittable(txInfo, time.Now().UnixMilli())
+	require.False(t, ok)
+	require.NotEmpty(t, reason)
+}
+
+func TestIsSubmittableInvalid(t *testing.T) {
+	txInfo := validWithdrawTxInfo(time.Now().Add(time.Hour).UnixMilli())
+	txInfo.GasAccountIndex = txInfo.FromAccountIndex
+	ok, reason := IsSubmittable(txInfo, time.Now().UnixMilli())
+	require.False(t, ok)
+	require.NotEmpty(t, reason)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// DustSwapAmount is the minimum AssetAAmount a swap may move. Swaps below this
+// threshold are not economically worth the L1 settlement cost they impose.
+var DustSwapAmount = big.NewInt(1000)
+
+// SwapTxInfo describes a single-hop swap against a liquidity pool.
+type SwapTxInfo struct {
+	FromAccountIndex  int64
+	PairIndex         int64
+	AssetAId          int64
+	AssetAAmount      *big.Int
+	AssetBId          int64
+	AssetBAmount      *big.Int
+	GasAccountIndex   int64
+	GasFeeAssetId     int64
+	GasFeeAssetAmount *big.Int
+	ExpiredAt         int64
+	Nonce             int64
+}
+
+// PoolState is the subset of a liquidity pool account's state needed to bound
+// a swap against it.
+type PoolState struct {
+	PairIndex    int64
+	AssetAId     int64
+	AssetAAmount *big.Int
+	AssetBId     int64
+	AssetBAmount *big.Int
+}
+
+// ValidateSwapAmounts enforces that a swap moves at least the dust minimum of
+// AssetA and strictly less than the pool's available reserve of AssetB.
+func ValidateSwapAmounts(swap *SwapTxInfo, pool PoolState) error {
+	if swap == nil {
+		return errors.New("swap should not be nil")
+	}
+	if swap.PairIndex != pool.PairIndex {
+		return errors.New("swap PairIndex does not match pool PairIndex")
+	}
+	if swap.AssetAId != pool.AssetAId || swap.AssetBId != pool.AssetBId {
+		return errors.New("swap asset ids do not match pool asset ids")
+	}
+	if swap.AssetAAmount == nil || swap.AssetBAmount == nil {
+		return errors.New("swap amounts should not be nil")
+	}
+	if swap.AssetAAmount.Cmp(DustSwapAmount) < 0 {
+		return errors.New("swap AssetAAmount is below the dust minimum")
+	}
+	if err := ValidateAboveDustThreshold(swap.AssetAId, swap.AssetAAmount); err != nil {
+		return err
+	}
+	if pool.AssetBAmount == nil || swap.AssetBAmount.Cmp(pool.AssetBAmount) >= 0 {
+		return errors.New("swap AssetBAmount exceeds the pool's available reserve")
+	}
+	return nil
+}
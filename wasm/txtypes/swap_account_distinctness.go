@@ -0,0 +1,41 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import "errors"
+
+// ValidateDistinctTreasuryAndPoolAccounts checks that a swap's treasury
+// account and pool account are not the same account index. If they
+// coincided, a pool's reserves and the protocol's collected fees would
+// settle into one and the same balance, so a pool could be drained by
+// routing fee credits back into its own reserve.
+//
+// Neither SwapTxInfo nor PoolState in this package actually carries a
+// treasury account index or a pool account index today: a swap's fee is
+// credited to GasAccountIndex (see AtomicMatchTxInfo's identical
+// GasAccountIndex-as-treasury pattern), and a pool is identified by
+// PairIndex rather than by an account leaf of its own. This function records
+// the invariant those fields would need to satisfy if this package grows
+// them; until then it is a standalone check. The in-circuit counterpart is
+// types.AssertDistinctTreasuryAndPoolAccounts.
+func ValidateDistinctTreasuryAndPoolAccounts(treasuryAccountIndex, poolAccountIndex int64) error {
+	if treasuryAccountIndex == poolAccountIndex {
+		return errors.New("treasury account index must not equal the pool account index")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package txtypes
+
+import "testing"
+
+func TestValidateDistinctTreasuryAndPoolAccountsRejectsCoincidence(t *testing.T) {
+	if err := ValidateDistinctTreasuryAndPoolAccounts(7, 7); err == nil {
+		t.Fatalf("expected an error when the treasury account equals the pool account")
+	}
+	if err := ValidateDistinctTreasuryAndPoolAccounts(7, 8); err != nil {
+		t.Fatalf("ValidateDistinctTreasuryAndPoolAccounts with distinct accounts failed: %v", err)
+	}
+}
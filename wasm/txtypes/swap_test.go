@@ -0,0 +1,110 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/util"
+)
+
+func TestValidateSwapAmounts(t *testing.T) {
+	pool := PoolState{
+		PairIndex:    1,
+		AssetAId:     0,
+		AssetAAmount: big.NewInt(1000000),
+		AssetBId:     1,
+		AssetBAmount: big.NewInt(2000000),
+	}
+
+	// below dust
+	err := ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    1,
+		AssetAId:     0,
+		AssetAAmount: big.NewInt(1),
+		AssetBId:     1,
+		AssetBAmount: big.NewInt(1),
+	}, pool)
+	require.Error(t, err)
+
+	// above pool reserve
+	err = ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    1,
+		AssetAId:     0,
+		AssetAAmount: big.NewInt(5000),
+		AssetBId:     1,
+		AssetBAmount: big.NewInt(3000000),
+	}, pool)
+	require.Error(t, err)
+
+	// equal to pool reserve
+	err = ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    1,
+		AssetAId:     0,
+		AssetAAmount: big.NewInt(5000),
+		AssetBId:     1,
+		AssetBAmount: big.NewInt(2000000),
+	}, pool)
+	require.Error(t, err)
+
+	// within bounds
+	err = ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    1,
+		AssetAId:     0,
+		AssetAAmount: big.NewInt(5000),
+		AssetBId:     1,
+		AssetBAmount: big.NewInt(9000),
+	}, pool)
+	require.NoError(t, err)
+}
+
+func TestValidateSwapAmountsRejectsBelowRegisteredDustThreshold(t *testing.T) {
+	const dustAsset = int64(22)
+	require.NoError(t, util.RegisterDustThreshold(dustAsset, big.NewInt(10000)))
+
+	pool := PoolState{
+		PairIndex:    2,
+		AssetAId:     dustAsset,
+		AssetAAmount: big.NewInt(1000000),
+		AssetBId:     23,
+		AssetBAmount: big.NewInt(2000000),
+	}
+
+	// above the package-wide DustSwapAmount floor, but below the asset's own
+	// registered dust threshold
+	err := ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    2,
+		AssetAId:     dustAsset,
+		AssetAAmount: big.NewInt(5000),
+		AssetBId:     23,
+		AssetBAmount: big.NewInt(9000),
+	}, pool)
+	require.Error(t, err)
+
+	err = ValidateSwapAmounts(&SwapTxInfo{
+		PairIndex:    2,
+		AssetAId:     dustAsset,
+		AssetAAmount: big.NewInt(10000),
+		AssetBId:     23,
+		AssetBAmount: big.NewInt(9000),
+	}, pool)
+	require.NoError(t, err)
+}
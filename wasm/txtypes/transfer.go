@@ -46,6 +46,9 @@ type TransferSegmentFormat struct {
 	CallData          string `json:"call_data"`
 	ExpiredAt         int64  `json:"expired_at"`
 	Nonce             int64  `json:"nonce"`
+	// ToL1Address is optional: when set, this transfer is directed at an L1
+	// address for bridging rather than (or in addition to) ToAccountIndex.
+	ToL1Address string `json:"to_l1_address"`
 }
 
 func ConstructTransferTxInfo(sk *PrivateKey, segmentStr string) (txInfo *TransferTxInfo, err error) {
@@ -80,6 +83,7 @@ func ConstructTransferTxInfo(sk *PrivateKey, segmentStr string) (txInfo *Transfe
 		CallData:          segmentFormat.CallData,
 		ExpiredAt:         segmentFormat.ExpiredAt,
 		Nonce:             segmentFormat.Nonce,
+		ToL1Address:       segmentFormat.ToL1Address,
 		Sig:               nil,
 	}
 	// compute call data hash
@@ -119,7 +123,10 @@ type TransferTxInfo struct {
 	CallDataHash      []byte
 	ExpiredAt         int64
 	Nonce             int64
-	Sig               []byte
+	// ToL1Address is optional: when set, this transfer is directed at an L1
+	// address for bridging rather than (or in addition to) ToAccountIndex.
+	ToL1Address string
+	Sig         []byte
 }
 
 func (txInfo *TransferTxInfo) Validate() error {
@@ -153,6 +160,9 @@ func (txInfo *TransferTxInfo) Validate() error {
 	if txInfo.AssetAmount.Cmp(maxAssetAmount) > 0 {
 		return ErrAssetAmountTooHigh
 	}
+	if err := ValidateAboveDustThreshold(txInfo.AssetId, txInfo.AssetAmount); err != nil {
+		return err
+	}
 
 	if txInfo.GasAccountIndex < minAccountIndex {
 		return ErrGasAccountIndexTooLow
@@ -182,6 +192,11 @@ func (txInfo *TransferTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	// ToAccountNameHash
 	if !IsValidHash(txInfo.ToAccountNameHash) {
 		return ErrToAccountNameHashInvalid
@@ -192,6 +207,11 @@ func (txInfo *TransferTxInfo) Validate() error {
 		return ErrCallDataHashInvalid
 	}
 
+	// ToL1Address is optional; validate it only when the caller set it.
+	if txInfo.ToL1Address != "" && !IsValidL1Address(txInfo.ToL1Address) {
+		return ErrToL1AddressInvalid
+	}
+
 	return nil
 }
 
@@ -253,6 +273,7 @@ func (txInfo *TransferTxInfo) Hash(hFunc hash.Hash) (msgHash []byte, err error)
 	WriteInt64IntoBuf(&buf, txInfo.ToAccountIndex, txInfo.AssetId, packedAmount)
 	buf.Write(ffmath.Mod(new(big.Int).SetBytes(common.FromHex(txInfo.ToAccountNameHash)), curve.Modulus).FillBytes(make([]byte, 32)))
 	buf.Write(ffmath.Mod(new(big.Int).SetBytes(txInfo.CallDataHash), curve.Modulus).FillBytes(make([]byte, 32)))
+	buf.Write(PaddingAddressToBytes32(txInfo.ToL1Address))
 	hFunc.Write(buf.Bytes())
 	msgHash = hFunc.Sum(nil)
 	return msgHash, nil
@@ -26,6 +26,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/zkbnb-crypto/util"
 )
 
 func TestValidateTransferTxInfo(t *testing.T) {
@@ -286,3 +288,26 @@ func TestValidateTransferTxInfo(t *testing.T) {
 		require.Equalf(t, testCase.err, err, "err should be the same")
 	}
 }
+
+func TestValidateTransferTxInfoRejectsBelowDustThreshold(t *testing.T) {
+	const dustAsset = int64(21)
+	require.NoError(t, util.RegisterDustThreshold(dustAsset, big.NewInt(1000)))
+
+	txInfo := &TransferTxInfo{
+		FromAccountIndex:  1,
+		ToAccountIndex:    1,
+		AssetId:           dustAsset,
+		AssetAmount:       big.NewInt(999),
+		GasAccountIndex:   0,
+		GasFeeAssetId:     3,
+		GasFeeAssetAmount: big.NewInt(100),
+		ExpiredAt:         time.Now().Add(time.Hour).UnixMilli(),
+		Nonce:             1,
+		ToAccountNameHash: hex.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+		CallDataHash:      bytes.Repeat([]byte{1}, 32),
+	}
+	require.Error(t, txInfo.Validate())
+
+	txInfo.AssetAmount = big.NewInt(1000)
+	require.NoError(t, txInfo.Validate())
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/twistededwards/eddsa"
 	"github.com/ethereum/go-ethereum/common"
 
+	curve "github.com/bnb-chain/zkbnb-crypto/ecc/ztwistededwards/tebn254"
 	"github.com/bnb-chain/zkbnb-crypto/util"
 )
 
@@ -58,6 +59,10 @@ func StringToBigInt(a string) (res *big.Int, err error) {
 	if a == "" {
 		return big.NewInt(0), nil
 	}
+	if !isCanonicalDecimalString(a) {
+		log.Println("[StringToBigInt] amount string is not in canonical decimal form")
+		return nil, errors.New("[StringToBigInt] amount string is not in canonical decimal form")
+	}
 	res, isValid := new(big.Int).SetString(a, 10)
 	if !isValid {
 		log.Println("[StringToBigInt] invalid string to big int")
@@ -66,6 +71,22 @@ func StringToBigInt(a string) (res *big.Int, err error) {
 	return res, nil
 }
 
+// isCanonicalDecimalString reports whether a is the canonical decimal form of
+// a non-negative integer: only digits, no sign, and no leading zeros unless
+// the value is exactly "0". This rejects ambiguous forms like "007" so an
+// amount string has exactly one valid representation.
+func isCanonicalDecimalString(a string) bool {
+	for _, c := range a {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	if a == "0" {
+		return true
+	}
+	return a[0] != '0'
+}
+
 func PaddingStringToBytes32(name string) []byte {
 	buf := make([]byte, 32)
 	copy(buf, name)
@@ -77,7 +98,7 @@ func PaddingAddressToBytes32(addr string) []byte {
 }
 
 /*
-	ToPackedAmount: convert big int to 40 bit, 5 bits for 10^x, 35 bits for a * 10^x
+ToPackedAmount: convert big int to 40 bit, 5 bits for 10^x, 35 bits for a * 10^x
 */
 func ToPackedAmount(amount *big.Int) (res int64, err error) {
 	return util.ToPackedAmount(amount)
@@ -88,7 +109,7 @@ func CleanPackedAmount(amount *big.Int) (nAmount *big.Int, err error) {
 }
 
 /*
-	ToPackedFee: convert big int to 16 bit, 5 bits for 10^x, 11 bits for a * 10^x
+ToPackedFee: convert big int to 16 bit, 5 bits for 10^x, 11 bits for a * 10^x
 */
 func ToPackedFee(amount *big.Int) (res int64, err error) {
 	return util.ToPackedFee(amount)
@@ -98,6 +119,35 @@ func CleanPackedFee(amount *big.Int) (nAmount *big.Int, err error) {
 	return util.CleanPackedFee(amount)
 }
 
+// ToPackedFeeForAsset is the asset-aware counterpart of ToPackedFee: it
+// rejects fees that would need to be rounded more coarsely than assetId's
+// registered precision (see util.RegisterGasFeePrecision) allows.
+func ToPackedFeeForAsset(assetId int64, amount *big.Int) (res int64, err error) {
+	return util.ToPackedFeeWithPrecision(assetId, amount)
+}
+
+// ValidateAboveDustThreshold is the asset-aware dust check used when
+// validating a transfer or swap amount: it rejects amounts below assetId's
+// registered dust threshold (see util.RegisterDustThreshold), an amount too
+// small to be worth the L1 settlement cost it would impose. An asset with no
+// registered threshold allows any non-negative amount through unchanged.
+func ValidateAboveDustThreshold(assetId int64, amount *big.Int) error {
+	return util.ValidateAboveDustThreshold(assetId, amount)
+}
+
+// ValidateBaseUnitScale is the asset-aware scale check for an amount a
+// caller also has in display-unit form: it rejects amount unless it equals
+// displayAmount scaled to assetId's registered decimals (see
+// util.RegisterAssetDecimals), catching display/base-unit mixups before an
+// amount is packed into a tx. No tx type here carries a separate
+// display-unit field to check against, so this is not wired into any
+// Validate method; it is exposed for callers upstream of this package, such
+// as a wallet, that do have both forms available. An asset with no
+// registered decimals allows any amount through unchanged.
+func ValidateBaseUnitScale(assetId int64, amount *big.Int, displayAmount string) error {
+	return util.ValidateBaseUnitScale(assetId, amount, displayAmount)
+}
+
 func FromHex(s string) ([]byte, error) {
 	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
 		s = s[2:]
@@ -142,6 +192,41 @@ func IsValidL1Address(address string) bool {
 	return common.IsHexAddress(address)
 }
 
+// ValidateGasAccountDiffersFromAccount checks that gasAccountIndex does not
+// pay gas fees to the same account that is paying them. It is opt-in per tx
+// type: tx types where paying gas to yourself is sensible (e.g. an ordinary
+// transfer, where the signer can also be the block's gas collector) should
+// not call this; withdraw-style tx types, where the paying account leaves
+// the L2 entirely, should.
+func ValidateGasAccountDiffersFromAccount(accountIndex, gasAccountIndex int64) error {
+	if accountIndex == gasAccountIndex {
+		return ErrGasAccountIndexSameAsAccountIndex
+	}
+	return nil
+}
+
+// minPlausibleExpiredAtMillis is a lower bound on a plausible ExpiredAt,
+// expressed in milliseconds since the Unix epoch (2001-09-09). ExpiredAt is
+// documented and signed as milliseconds throughout this package; a caller
+// that mistakenly passes seconds produces a value many orders of magnitude
+// below this bound, so rejecting anything smaller catches the mix-up before
+// it causes either immediate expiry or a tx that is valid for millennia.
+const minPlausibleExpiredAtMillis = 1_000_000_000_000
+
+// ValidateExpiredAtIsMilliseconds checks that expiredAt is plausible as
+// milliseconds since the Unix epoch, rejecting a seconds-valued timestamp
+// passed in by mistake. NilExpiredAt, used by tx types that do not carry a
+// real expiry, is exempt.
+func ValidateExpiredAtIsMilliseconds(expiredAt int64) error {
+	if expiredAt == NilExpiredAt {
+		return nil
+	}
+	if expiredAt < minPlausibleExpiredAtMillis {
+		return ErrExpiredAtNotMilliseconds
+	}
+	return nil
+}
+
 func ParsePublicKey(pkStr string) (pk *eddsa.PublicKey, err error) {
 	pkBytes, err := hex.DecodeString(pkStr)
 	if err != nil {
@@ -155,5 +240,8 @@ func ParsePublicKey(pkStr string) (pk *eddsa.PublicKey, err error) {
 	if size != 32 {
 		return nil, errors.New("invalid public key")
 	}
+	if err = curve.ValidateSubGroup(&pk.A); err != nil {
+		return nil, err
+	}
 	return pk, nil
 }
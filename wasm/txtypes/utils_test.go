@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringToBigIntRejectsLeadingZeros(t *testing.T) {
+	_, err := StringToBigInt("007")
+	require.Error(t, err)
+}
+
+func TestStringToBigIntAcceptsCanonicalZero(t *testing.T) {
+	res, err := StringToBigInt("0")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), res)
+}
+
+func TestStringToBigIntAcceptsCanonicalDecimal(t *testing.T) {
+	res, err := StringToBigInt("100")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), res)
+}
+
+func TestValidateExpiredAtIsMillisecondsRejectsSecondsValue(t *testing.T) {
+	// A seconds-since-epoch timestamp, e.g. time.Now().Unix(), is many orders
+	// of magnitude below minPlausibleExpiredAtMillis and must be flagged.
+	err := ValidateExpiredAtIsMilliseconds(1754611200)
+	require.Equal(t, ErrExpiredAtNotMilliseconds, err)
+}
+
+func TestValidateExpiredAtIsMillisecondsAcceptsMillisValue(t *testing.T) {
+	err := ValidateExpiredAtIsMilliseconds(1754611200000)
+	require.NoError(t, err)
+}
+
+func TestValidateExpiredAtIsMillisecondsAcceptsNilExpiredAt(t *testing.T) {
+	err := ValidateExpiredAtIsMilliseconds(NilExpiredAt)
+	require.NoError(t, err)
+}
@@ -135,6 +135,9 @@ func (txInfo *WithdrawTxInfo) Validate() error {
 	if txInfo.GasAccountIndex > maxAccountIndex {
 		return ErrGasAccountIndexTooHigh
 	}
+	if err := ValidateGasAccountDiffersFromAccount(txInfo.FromAccountIndex, txInfo.GasAccountIndex); err != nil {
+		return err
+	}
 
 	if txInfo.GasFeeAssetId < minAssetId {
 		return ErrGasFeeAssetIdTooLow
@@ -157,6 +160,11 @@ func (txInfo *WithdrawTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	// ToAddress
 	if !IsValidL1Address(txInfo.ToAddress) {
 		return ErrToAddressInvalid
@@ -131,6 +131,9 @@ func (txInfo *WithdrawNftTxInfo) Validate() error {
 	if txInfo.GasAccountIndex > maxAccountIndex {
 		return ErrGasAccountIndexTooHigh
 	}
+	if err := ValidateGasAccountDiffersFromAccount(txInfo.AccountIndex, txInfo.GasAccountIndex); err != nil {
+		return err
+	}
 
 	// GasFeeAssetId
 	if txInfo.GasFeeAssetId < minAssetId {
@@ -156,6 +159,11 @@ func (txInfo *WithdrawNftTxInfo) Validate() error {
 		return ErrNonceTooLow
 	}
 
+	// ExpiredAt
+	if err := ValidateExpiredAtIsMilliseconds(txInfo.ExpiredAt); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -93,6 +93,22 @@ func TestValidateWithdrawNftTxInfo(t *testing.T) {
 				GasAccountIndex:        maxAccountIndex + 1,
 			},
 		},
+		// GasAccountIndex same as AccountIndex
+		{
+			fmt.Errorf("GasAccountIndex should not be the same as the paying account index"),
+			&WithdrawNftTxInfo{
+				AccountIndex:           1,
+				CreatorAccountIndex:    1,
+				CreatorAccountNameHash: bytes.Repeat([]byte{1}, 32),
+				NftIndex:               5,
+				NftContentHash:         bytes.Repeat([]byte{1}, 32),
+				NftL1Address:           "0x299d17c8b4e9967385dc9a3bb78f2a43f5a13bd9",
+				NftL1TokenId:           big.NewInt(11),
+				CollectionId:           11,
+				ToAddress:              "0x299d17c8b4e9967385dc9a3bb78f2a43f5a13bd0",
+				GasAccountIndex:        1,
+			},
+		},
 		// GasFeeAssetId
 		{
 			fmt.Errorf("GasFeeAssetId should not be less than %d", minAssetId),
@@ -0,0 +1,43 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"bytes"
+)
+
+// WithdrawMsgPreimage returns the exact bytes Hash() writes into the hash
+// function before summing, matching what circuit/types.ComputeHashFromWithdrawTx
+// writes into its MiMC hasher field by field. It exists to debug withdraw
+// signature mismatches: diffing this against the bytes the circuit actually
+// consumed narrows a mismatch down to a single field instead of an opaque
+// hash. nonce is taken as a parameter rather than read off txInfo, mirroring
+// how ComputeHashFromWithdrawTx takes nonce separately from the tx witness.
+func WithdrawMsgPreimage(txInfo *WithdrawTxInfo, nonce int64) ([]byte, error) {
+	var buf bytes.Buffer
+	packedFee, err := ToPackedFee(txInfo.GasFeeAssetAmount)
+	if err != nil {
+		return nil, err
+	}
+	WriteInt64IntoBuf(&buf, ChainId, txInfo.FromAccountIndex, nonce, txInfo.ExpiredAt)
+	WriteInt64IntoBuf(&buf, txInfo.GasAccountIndex, txInfo.GasFeeAssetId, packedFee)
+	WriteInt64IntoBuf(&buf, txInfo.AssetId)
+	WriteBigIntIntoBuf(&buf, txInfo.AssetAmount)
+	buf.Write(PaddingAddressToBytes32(txInfo.ToAddress))
+	return buf.Bytes(), nil
+}
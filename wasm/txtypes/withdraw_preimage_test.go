@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2022 ZkBNB Protocol
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txtypes
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawMsgPreimage(t *testing.T) {
+	txInfo := &WithdrawTxInfo{
+		FromAccountIndex:  1,
+		AssetId:           3,
+		AssetAmount:       big.NewInt(100),
+		GasAccountIndex:   4,
+		GasFeeAssetId:     0,
+		GasFeeAssetAmount: big.NewInt(1),
+		ExpiredAt:         1000,
+		Nonce:             1,
+		ToAddress:         "0x299D17c8B4e9967385dC9a3Bb78F2A43F5a13bD0",
+	}
+
+	preimage, err := WithdrawMsgPreimage(txInfo, txInfo.Nonce)
+	require.NoError(t, err)
+	require.Equal(t,
+		"00000000000000010000000000000001000000000000000100000000000003e8000000000000000000000000000000040000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000064000000000000000000000000299d17c8b4e9967385dc9a3bb78f2a43f5a13bd0",
+		hex.EncodeToString(preimage))
+
+	// hashing the preimage directly matches Hash() when nonce agrees
+	hFunc := mimc.NewMiMC()
+	hFunc.Write(preimage)
+	wantFromHFunc := hFunc.Sum(nil)
+
+	want, err := txInfo.Hash(mimc.NewMiMC())
+	require.NoError(t, err)
+	require.Equal(t, want, wantFromHFunc)
+
+	// a wrong nonce produces a different preimage, catching a stale-nonce bug
+	stale, err := WithdrawMsgPreimage(txInfo, txInfo.Nonce+1)
+	require.NoError(t, err)
+	require.NotEqual(t, preimage, stale)
+}
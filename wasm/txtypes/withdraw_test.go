@@ -102,6 +102,16 @@ func TestValidateWithdrawTxInfo(t *testing.T) {
 				GasAccountIndex:  maxAccountIndex + 1,
 			},
 		},
+		// GasAccountIndex same as FromAccountIndex
+		{
+			fmt.Errorf("GasAccountIndex should not be the same as the paying account index"),
+			&WithdrawTxInfo{
+				FromAccountIndex: 1,
+				AssetId:          1,
+				AssetAmount:      big.NewInt(1),
+				GasAccountIndex:  1,
+			},
+		},
 		// GasFeeAssetId
 		{
 			fmt.Errorf("GasFeeAssetId should not be less than %d", minAssetId),